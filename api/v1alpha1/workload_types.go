@@ -0,0 +1,319 @@
+// File: api/v1alpha1/workload_types.go
+package v1alpha1
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GitRef selects the revision of the Git repository to build.
+// Exactly one of Branch, Tag, Commit or Semver must be set.
+type GitRef struct {
+	// Branch is the branch whose head commit is built.
+	// +optional
+	Branch string `json:"branch,omitempty"`
+
+	// Tag is the tag whose commit is built.
+	// +optional
+	Tag string `json:"tag,omitempty"`
+
+	// Commit pins the build to a full 40 character commit SHA.
+	// +optional
+	Commit string `json:"commit,omitempty"`
+
+	// Semver is a version range (e.g. ">=1.2.0 <2.0.0") resolved against the
+	// remote tags; the highest matching tag is built.
+	// +optional
+	Semver string `json:"semver,omitempty"`
+}
+
+// GitSource describes the Git repository a Workload is built from.
+type GitSource struct {
+	// URL is the clone URL of the repository (https:// or git@host:path).
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// +optional
+	Ref GitRef `json:"ref,omitempty"`
+
+	// PollInterval overrides the controller-wide interval at which the ref is
+	// re-resolved to detect new commits. Zero disables polling for this Workload.
+	// +optional
+	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
+}
+
+// Source describes where the Workload source code comes from.
+type Source struct {
+	// +optional
+	Git *GitSource `json:"git,omitempty"`
+}
+
+// EnvVar is a single name/value environment variable.
+type EnvVar struct {
+	// +optional
+	Name string `json:"name,omitempty"`
+	// +optional
+	Value string `json:"value,omitempty"`
+}
+
+// ConcurrencyPolicy describes how a new PipelineRun is started while an earlier
+// PipelineRun of the same Workload is still running.
+// +kubebuilder:validation:Enum=Allow;Forbid;Replace
+type ConcurrencyPolicy string
+
+const (
+	// ConcurrencyAllow starts new PipelineRuns alongside running ones.
+	ConcurrencyAllow ConcurrencyPolicy = "Allow"
+	// ConcurrencyForbid waits for running PipelineRuns to finish before starting a new one.
+	ConcurrencyForbid ConcurrencyPolicy = "Forbid"
+	// ConcurrencyReplace cancels running PipelineRuns and starts the new one.
+	ConcurrencyReplace ConcurrencyPolicy = "Replace"
+)
+
+// BuildSpec configures the build stage of the pipeline.
+type BuildSpec struct {
+	// Env is passed to the image build.
+	// +optional
+	Env []EnvVar `json:"env,omitempty"`
+
+	// ConcurrencyPolicy controls overlapping PipelineRuns of this Workload,
+	// which share the same workspace PVC. Defaults to Allow.
+	// +optional
+	ConcurrencyPolicy ConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+
+	// History limits how many completed PipelineRuns of this Workload are kept.
+	// Unset limits fall back to the controller configuration.
+	// +optional
+	History *BuildHistory `json:"history,omitempty"`
+}
+
+// BuildHistory limits the number of completed PipelineRuns kept per Workload.
+// The PipelineRun referenced by status.lastPipelineRunName is never pruned.
+type BuildHistory struct {
+	// SuccessfulRunsLimit is the number of succeeded PipelineRuns to keep.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	SuccessfulRunsLimit *int32 `json:"successfulRunsLimit,omitempty"`
+
+	// FailedRunsLimit is the number of failed PipelineRuns to keep.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	FailedRunsLimit *int32 `json:"failedRunsLimit,omitempty"`
+}
+
+// ResourceRequests lists the requested compute resources as quantity strings.
+type ResourceRequests struct {
+	// +optional
+	CPU string `json:"cpu,omitempty"`
+	// +optional
+	Memory string `json:"memory,omitempty"`
+}
+
+// Resources describes the compute resources of the Workload.
+type Resources struct {
+	// +optional
+	Requests *ResourceRequests `json:"requests,omitempty"`
+}
+
+// Param is a named pipeline parameter. Value keeps the raw JSON as written
+// by the user so that strings, arrays and objects are all accepted.
+type Param struct {
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Value apiextensionsv1.JSON `json:"value"`
+}
+
+// PipelineRef selects the Pipeline run for a Workload. Exactly one field is set.
+type PipelineRef struct {
+	// Name of a Pipeline in the Workload's namespace.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Catalog is the name of a Pipeline in the controller's catalog namespace,
+	// run through the Tekton cluster resolver.
+	// +optional
+	Catalog string `json:"catalog,omitempty"`
+
+	// Resolver fetches the Pipeline through a Tekton remote resolver.
+	// +optional
+	Resolver *ResolverRef `json:"resolver,omitempty"`
+}
+
+// ResolverRef references a Pipeline through a Tekton remote resolver.
+type ResolverRef struct {
+	// Resolver is the Tekton resolver that fetches the Pipeline.
+	// +kubebuilder:validation:Enum=bundles;git;cluster
+	Resolver string `json:"resolver"`
+
+	// Params are passed to the resolver, e.g. bundle, name and kind for bundles.
+	// +optional
+	Params []ResolverParam `json:"params,omitempty"`
+
+	// Workspaces lists the workspaces the Pipeline declares. The controller does not
+	// read remote Pipelines, so only these workspaces are bound.
+	// +optional
+	Workspaces []string `json:"workspaces,omitempty"`
+}
+
+// ResolverParam is a single resolver parameter.
+type ResolverParam struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// WorkloadSpec defines the desired state of Workload.
+type WorkloadSpec struct {
+	// +optional
+	Build *BuildSpec `json:"build,omitempty"`
+
+	// +optional
+	Env []EnvVar `json:"env,omitempty"`
+
+	// PipelineRef selects the Pipeline for this Workload. Defaults to the
+	// controller's configured Pipeline in the Workload's namespace.
+	// +optional
+	PipelineRef *PipelineRef `json:"pipelineRef,omitempty"`
+
+	// +optional
+	Resources *Resources `json:"resources,omitempty"`
+
+	// +optional
+	Source *Source `json:"source,omitempty"`
+
+	Params []Param `json:"params"`
+}
+
+// Annotations recognised on Workloads.
+const (
+	// AnnotationBuildGitSecret names the Secret used to authenticate Git resolution.
+	AnnotationBuildGitSecret = "tekton.platform/build-git-secret"
+	// AnnotationBuildGitToken holds an access token used instead of the Git Secret.
+	AnnotationBuildGitToken = "tekton.platform/build-git-token"
+	// AnnotationBuildWorkspaceClaim names the PersistentVolumeClaim bound to the shared-data workspace.
+	AnnotationBuildWorkspaceClaim = "tekton.platform/build-workspace-claim"
+)
+
+// Condition types reported in WorkloadStatus.Conditions.
+const (
+	// ConditionSourceResolved is True once the Git ref has been resolved to a commit SHA.
+	ConditionSourceResolved = "SourceResolved"
+	// ConditionPipelineRunCreated is True once a PipelineRun exists for the resolved commit.
+	ConditionPipelineRunCreated = "PipelineRunCreated"
+	// ConditionRoutingReady is True once the listener HTTPProxy and global include are in place.
+	ConditionRoutingReady = "RoutingReady"
+	// ConditionBuildSucceeded mirrors the Succeeded condition of the latest PipelineRun.
+	ConditionBuildSucceeded = "BuildSucceeded"
+	// ConditionReady summarizes the other conditions.
+	ConditionReady = "Ready"
+)
+
+// Values of WorkloadStatus.Phase.
+const (
+	PhasePending = "Pending"
+	PhaseReady   = "Ready"
+	PhaseFailed  = "Failed"
+)
+
+// WorkloadStatus defines the observed state of Workload.
+type WorkloadStatus struct {
+	// ObservedGeneration is the generation last processed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// +optional
+	LastAppliedRevision string `json:"lastAppliedRevision,omitempty"`
+
+	// LastResolvedRef is the ref name spec.source.git.ref last resolved to
+	// (refs/heads/<branch>, refs/tags/<tag> or the pinned commit).
+	// +optional
+	LastResolvedRef string `json:"lastResolvedRef,omitempty"`
+
+	// +optional
+	LastCommitSHA string `json:"lastCommitSHA,omitempty"`
+
+	// +optional
+	LastPipelineRunName string `json:"lastPipelineRunName,omitempty"`
+
+	// LastBuildHash is the hash of the pipeline, params and workspaces used for
+	// LastPipelineRunName. A new PipelineRun is only created when it or the commit changes.
+	// +optional
+	LastBuildHash string `json:"lastBuildHash,omitempty"`
+
+	// LastPipelineRun mirrors the state of LastPipelineRunName.
+	// +optional
+	LastPipelineRun *PipelineRunStatus `json:"lastPipelineRun,omitempty"`
+
+	// IgnoredParams lists the params that were not passed to the PipelineRun
+	// because the Pipeline does not declare them.
+	// +optional
+	IgnoredParams []string `json:"ignoredParams,omitempty"`
+
+	// +optional
+	Phase string `json:"phase,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// PipelineRunStatus is a summary of a PipelineRun created for the Workload.
+type PipelineRunStatus struct {
+	// Name of the PipelineRun.
+	Name string `json:"name"`
+
+	// Succeeded is the status of the PipelineRun Succeeded condition: True, False or Unknown.
+	// +optional
+	Succeeded metav1.ConditionStatus `json:"succeeded,omitempty"`
+
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message is the Succeeded condition message, or that of FailedTaskRun when set.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// FailedTaskRun is the name of the first failed TaskRun of a failed PipelineRun.
+	// +optional
+	FailedTaskRun string `json:"failedTaskRun,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=wk
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Commit",type=string,JSONPath=`.status.lastCommitSHA`
+// +kubebuilder:printcolumn:name="PipelineRun",type=string,JSONPath=`.status.lastPipelineRunName`
+// +kubebuilder:printcolumn:name="Build",type=string,JSONPath=`.status.conditions[?(@.type=="BuildSucceeded")].reason`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// Workload is the Schema for the workloads API.
+type Workload struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   WorkloadSpec   `json:"spec,omitempty"`
+	Status WorkloadStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// WorkloadList contains a list of Workload.
+type WorkloadList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Workload `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Workload{}, &WorkloadList{})
+}
@@ -5,6 +5,17 @@ import (
     "time"
 
     "github.com/prometheus/client_golang/prometheus"
+    "k8s.io/apimachinery/pkg/api/meta"
+    "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+    workloadv1alpha1 "tekton-controller/api/v1alpha1"
+)
+
+// Reconcile outcomes recorded in workloadReconcileTotal.
+const (
+    outcomeSuccess = "success"
+    outcomeFailed  = "failed"
+    outcomeError   = "error"
 )
 
 var (
@@ -24,10 +35,77 @@ var (
             Buckets:   prometheus.DefBuckets,
         },
     )
+    httpProxyIncludeOps = prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Namespace: "tekton_controller",
+            Name:      "http_proxy_include_operations_total",
+            Help:      "Number of include updates on the global HTTPProxy, by operation and result.",
+        },
+        []string{"operation", "result"},
+    )
+    workloadReconcileTotal = prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Namespace: "tekton_controller",
+            Name:      "workload_reconcile_total",
+            Help:      "Number of Workload reconciles, by outcome and Ready condition reason.",
+        },
+        []string{"outcome", "reason"},
+    )
+    pipelineRunsCreated = prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Namespace: "tekton_controller",
+            Name:      "pipelineruns_created_total",
+            Help:      "Number of PipelineRuns created, by namespace.",
+        },
+        []string{"namespace"},
+    )
+    pipelineRunsPruned = prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Namespace: "tekton_controller",
+            Name:      "pipelineruns_pruned_total",
+            Help:      "Number of completed PipelineRuns deleted by history limits, by namespace.",
+        },
+        []string{"namespace"},
+    )
+    shaResolveDuration = prometheus.NewHistogramVec(
+        prometheus.HistogramOpts{
+            Namespace: "tekton_controller",
+            Name:      "git_sha_resolution_duration_seconds",
+            Help:      "Duration of Git ref to SHA resolution in Workload reconciles, including cache hits, by result.",
+            Buckets:   prometheus.DefBuckets,
+        },
+        []string{"result"},
+    )
+    namespaceCleanupTotal = prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Namespace: "tekton_controller",
+            Name:      "namespace_cleanup_total",
+            Help:      "Number of tekton-enabled namespace cleanups, by result.",
+        },
+        []string{"result"},
+    )
+    namespaceCleanupDeleted = prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Namespace: "tekton_controller",
+            Name:      "namespace_cleanup_deleted_total",
+            Help:      "Number of resources deleted by namespace cleanup, by kind.",
+        },
+        []string{"kind"},
+    )
 )
 
 func init() {
-    prometheus.MustRegister(reconcileCounter, reconcileDuration)
+    metrics.Registry.MustRegister(
+        reconcileCounter,
+        reconcileDuration,
+        httpProxyIncludeOps,
+        workloadReconcileTotal,
+        pipelineRunsCreated,
+        pipelineRunsPruned,
+        shaResolveDuration,
+        namespaceCleanupTotal,
+        namespaceCleanupDeleted,
+    )
 }
 
 // observe wraps a function f, recording metrics automatically.
@@ -36,12 +114,39 @@ func observe(f func() error) (err error) {
     defer func() {
         d := time.Since(start).Seconds()
         reconcileDuration.Observe(d)
-        if err != nil {
-            reconcileCounter.WithLabelValues("error").Inc()
-        } else {
-            reconcileCounter.WithLabelValues("success").Inc()
-        }
+        reconcileCounter.WithLabelValues(resultLabel(err)).Inc()
     }()
     err = f()
     return
 }
+
+// observeDuration records the duration of f in h, labelled by result.
+func observeDuration(h *prometheus.HistogramVec, f func() error) error {
+    start := time.Now()
+    err := f()
+    h.WithLabelValues(resultLabel(err)).Observe(time.Since(start).Seconds())
+    return err
+}
+
+// recordWorkloadReconcile counts a finished reconcile by outcome and the Ready condition reason.
+func recordWorkloadReconcile(wl *workloadv1alpha1.Workload, err error) {
+    outcome := outcomeSuccess
+    switch {
+    case err != nil:
+        outcome = outcomeError
+    case wl.Status.Phase == workloadv1alpha1.PhaseFailed:
+        outcome = outcomeFailed
+    }
+    reason := "Unknown"
+    if c := meta.FindStatusCondition(wl.Status.Conditions, workloadv1alpha1.ConditionReady); c != nil && c.Reason != "" {
+        reason = c.Reason
+    }
+    workloadReconcileTotal.WithLabelValues(outcome, reason).Inc()
+}
+
+func resultLabel(err error) string {
+    if err != nil {
+        return "error"
+    }
+    return "success"
+}
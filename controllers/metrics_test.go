@@ -0,0 +1,50 @@
+// File: controllers/metrics_test.go
+package controllers
+
+import (
+    "context"
+    "errors"
+    "testing"
+
+    "github.com/prometheus/client_golang/prometheus/testutil"
+    "github.com/stretchr/testify/assert"
+    metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+    "k8s.io/apimachinery/pkg/types"
+    ctrl "sigs.k8s.io/controller-runtime"
+
+    workloadv1alpha1 "tekton-controller/api/v1alpha1"
+    "tekton-controller/pkg/config"
+)
+
+func TestRecordWorkloadReconcile(t *testing.T) {
+    wl := &workloadv1alpha1.Workload{}
+    markFailed(wl, workloadv1alpha1.ConditionSourceResolved, reasonGitResolveFailed, "boom")
+
+    before := testutil.ToFloat64(workloadReconcileTotal.WithLabelValues(outcomeFailed, reasonGitResolveFailed))
+    recordWorkloadReconcile(wl, nil)
+    assert.Equal(t, before+1, testutil.ToFloat64(workloadReconcileTotal.WithLabelValues(outcomeFailed, reasonGitResolveFailed)))
+
+    markReady(wl)
+    before = testutil.ToFloat64(workloadReconcileTotal.WithLabelValues(outcomeError, reasonReconciled))
+    recordWorkloadReconcile(wl, errors.New("patch failed"))
+    assert.Equal(t, before+1, testutil.ToFloat64(workloadReconcileTotal.WithLabelValues(outcomeError, reasonReconciled)))
+}
+
+func TestReconcile_RecordsEarlyReturns(t *testing.T) {
+    // finalizer 만 추가하고 끝나는 reconcile 도 기록됩니다.
+    wl := &workloadv1alpha1.Workload{ObjectMeta: metav1.ObjectMeta{Namespace: "test-ns", Name: "app"}}
+    r, _ := newTestReconciler(t, wl)
+    r.Config = config.NewStore(config.Default())
+
+    before := testutil.ToFloat64(workloadReconcileTotal.WithLabelValues(outcomeSuccess, "Unknown"))
+    _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "test-ns", Name: "app"}})
+    assert.NoError(t, err)
+    assert.Equal(t, before+1, testutil.ToFloat64(workloadReconcileTotal.WithLabelValues(outcomeSuccess, "Unknown")))
+}
+
+func TestObserve(t *testing.T) {
+    before := testutil.ToFloat64(reconcileCounter.WithLabelValues("error"))
+    err := observe(func() error { return errors.New("boom") })
+    assert.Error(t, err)
+    assert.Equal(t, before+1, testutil.ToFloat64(reconcileCounter.WithLabelValues("error")))
+}
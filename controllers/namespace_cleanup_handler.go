@@ -11,12 +11,15 @@ import (
     "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
     "sigs.k8s.io/controller-runtime/pkg/client"
     ctrlLog "sigs.k8s.io/controller-runtime/pkg/log"
+
+    "tekton-controller/pkg/config"
+    "tekton-controller/pkg/pipeline"
 )
 
 // HandleNamespaceCleanup
 // - tekton-enabled:"true" 네임스페이스가 삭제되면 호출됩니다.
 // - 리스너 HTTPProxy, 글로벌 include, PipelineRun을 삭제하며 로그를 남깁니다.
-func HandleNamespaceCleanup(ctx context.Context, c client.Client, ns *unstructured.Unstructured) error {
+func HandleNamespaceCleanup(ctx context.Context, c client.Client, tekton *pipeline.Client, ns *unstructured.Unstructured, cfg config.Listener) error {
     logger := ctrlLog.FromContext(ctx)
     name := ns.GetName()
 
@@ -33,11 +36,14 @@ func HandleNamespaceCleanup(ctx context.Context, c client.Client, ns *unstructur
         logger.Error(err, "Failed to delete listener HTTPProxy", "listener", listenerName)
     } else {
         logger.Info("Deleted listener HTTPProxy", "listener", listenerName)
+        if err == nil {
+            namespaceCleanupDeleted.WithLabelValues(httpProxyKind).Inc()
+        }
     }
 
-    // 2) argocd/proxy-to-listener 글로벌 include 정리
+    // 2) 글로벌 HTTPProxy(기본 argocd/proxy-to-listener) include 정리
     logger.Info("Removing include from global HTTPProxy", "listener", listenerName)
-    if err := removeGlobalProxyInclude(ctx, c, listenerName, name); err != nil {
+    if err := removeGlobalProxyInclude(ctx, c, cfg, listenerName, name); err != nil {
         logger.Error(err, "Failed to remove global include", "listener", listenerName)
     } else {
         logger.Info("Removed global include", "listener", listenerName)
@@ -45,18 +51,21 @@ func HandleNamespaceCleanup(ctx context.Context, c client.Client, ns *unstructur
 
     // 3) 네임스페이스 내 모든 PipelineRun 삭제
     logger.Info("Deleting all PipelineRuns", "namespace", name)
-    prList := &unstructured.UnstructuredList{}
-    prList.SetGroupVersionKind(schema.GroupVersionKind{Group: "tekton.dev", Version: "v1beta1", Kind: "PipelineRunList"})
-    if err := c.List(ctx, prList, client.InNamespace(name)); err != nil {
+    runs, err := tekton.ListPipelineRuns(ctx, client.InNamespace(name))
+    if err != nil {
         logger.Error(err, "Failed to list PipelineRuns")
     } else {
-        for _, pr := range prList.Items {
-            prName := pr.GetName()
+        for i := range runs {
+            prName := runs[i].Name
             logger.Info("Deleting PipelineRun", "name", prName)
-            if err := c.Delete(ctx, &pr); err != nil && !errors.IsNotFound(err) {
+            if err := tekton.DeletePipelineRun(ctx, &runs[i]); err != nil && !errors.IsNotFound(err) {
                 logger.Error(err, "Failed to delete PipelineRun", "name", prName)
             } else {
                 logger.Info("Deleted PipelineRun", "name", prName)
+                // NotFound 는 이미 지워진 것이므로 세지 않습니다.
+                if err == nil {
+                    namespaceCleanupDeleted.WithLabelValues("PipelineRun").Inc()
+                }
             }
         }
     }
@@ -4,24 +4,41 @@ package controllers
 
 import (
     "context"
+    "errors"
 
     corev1 "k8s.io/api/core/v1"
     "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
     "k8s.io/apimachinery/pkg/runtime"
+    "k8s.io/client-go/tools/record"
     ctrl "sigs.k8s.io/controller-runtime"
     "sigs.k8s.io/controller-runtime/pkg/client"
     ctrlLog "sigs.k8s.io/controller-runtime/pkg/log"
+
+    "tekton-controller/pkg/config"
+    "tekton-controller/pkg/pipeline"
 )
 
-// NamespaceCleanupReconciler는 tekton-enabled:"true" 레이블의
+// NamespaceCleanupReconciler는 tekton-enabled:"true" 레이블(설정으로 변경 가능)의
 // 네임스페이스가 삭제되면 관련 리소스를 정리합니다.
 type NamespaceCleanupReconciler struct {
     client.Client
-    Scheme *runtime.Scheme
+    Scheme   *runtime.Scheme
+    Config   *config.Store
+    Recorder record.EventRecorder
+
+    // Tekton lists and deletes PipelineRuns in the configured tekton.dev version.
+    Tekton *pipeline.Client
 }
 
 // SetupWithManager에서 corev1.Namespace 이벤트를 Watch하도록 설정합니다.
 func (r *NamespaceCleanupReconciler) SetupWithManager(mgr ctrl.Manager) error {
+    if r.Recorder == nil {
+        r.Recorder = mgr.GetEventRecorderFor("namespace-cleanup-controller")
+    }
+    // Tekton 은 --tekton-api-version 에 맞춰 main.go 에서 만들어 넘깁니다.
+    if r.Tekton == nil {
+        return errors.New("no Tekton client set")
+    }
     return ctrl.NewControllerManagedBy(mgr).
         For(&corev1.Namespace{}).
         Complete(r)
@@ -46,7 +63,8 @@ func (r *NamespaceCleanupReconciler) Reconcile(ctx context.Context, req ctrl.Req
     }
 
     // 3) tekton-enabled:"true" 레이블이 아니면 리턴
-    if val := ns.Labels["tekton-enabled"]; val != "true" {
+    cfg := r.Config.Get()
+    if val := ns.Labels[cfg.TektonEnabledLabel]; val != "true" {
         logger.Info("Namespace is not tekton-enabled, skipping", "namespace", ns.Name)
         return ctrl.Result{}, nil
     }
@@ -63,8 +81,11 @@ func (r *NamespaceCleanupReconciler) Reconcile(ctx context.Context, req ctrl.Req
     }}
 
     // 5) cleanup 핸들러 호출
-    if err := HandleNamespaceCleanup(ctx, r.Client, u); err != nil {
+    err := HandleNamespaceCleanup(ctx, r.Client, r.Tekton, u, cfg.Listener)
+    namespaceCleanupTotal.WithLabelValues(resultLabel(err)).Inc()
+    if err != nil {
         logger.Error(err, "Namespace cleanup failed", "namespace", ns.Name)
+        r.Recorder.Eventf(&ns, corev1.EventTypeWarning, reasonCleanupFailed, "Namespace cleanup failed: %v", err)
         return ctrl.Result{}, err
     }
 
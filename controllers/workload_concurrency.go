@@ -0,0 +1,70 @@
+// File: controllers/workload_concurrency.go
+package controllers
+
+import (
+    "context"
+    "fmt"
+
+    pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+    corev1 "k8s.io/api/core/v1"
+    apierrors "k8s.io/apimachinery/pkg/api/errors"
+    "sigs.k8s.io/controller-runtime/pkg/client"
+
+    workloadv1alpha1 "tekton-controller/api/v1alpha1"
+    "tekton-controller/pkg/pipeline"
+)
+
+// concurrencyPolicy returns spec.build.concurrencyPolicy, defaulting to Allow.
+func concurrencyPolicy(wl *workloadv1alpha1.Workload) workloadv1alpha1.ConcurrencyPolicy {
+    if wl.Spec.Build == nil || wl.Spec.Build.ConcurrencyPolicy == "" {
+        return workloadv1alpha1.ConcurrencyAllow
+    }
+    return wl.Spec.Build.ConcurrencyPolicy
+}
+
+// listWorkloadPipelineRuns lists the PipelineRuns labeled with the Workload's name.
+func (r *WorkloadReconciler) listWorkloadPipelineRuns(ctx context.Context, wl *workloadv1alpha1.Workload) ([]pipelinev1.PipelineRun, error) {
+    runs, err := r.Tekton.ListPipelineRuns(ctx, client.InNamespace(wl.Namespace),
+        client.MatchingLabels{pipeline.WorkloadNameParam: wl.Name})
+    if err != nil {
+        return nil, fmt.Errorf("failed to list PipelineRuns: %w", err)
+    }
+    return runs, nil
+}
+
+// applyConcurrencyPolicy is called before PipelineRun next is created. Under Forbid it
+// returns the name of a running PipelineRun that next has to wait for; under Replace
+// it cancels every running PipelineRun. An empty name means next may be created.
+func (r *WorkloadReconciler) applyConcurrencyPolicy(ctx context.Context, wl *workloadv1alpha1.Workload, next string) (string, error) {
+    policy := concurrencyPolicy(wl)
+    if policy == workloadv1alpha1.ConcurrencyAllow {
+        return "", nil
+    }
+    runs, err := r.listWorkloadPipelineRuns(ctx, wl)
+    if err != nil {
+        return "", err
+    }
+
+    for i := range runs {
+        pr := &runs[i]
+        if pr.Name == next || pr.IsDone() {
+            continue
+        }
+        if policy == workloadv1alpha1.ConcurrencyForbid {
+            return pr.Name, nil
+        }
+        // Replace: 이미 취소 요청된 실행은 Tekton 이 정리하도록 둡니다.
+        if pr.IsCancelled() {
+            continue
+        }
+        if err := r.Tekton.CancelPipelineRun(ctx, pr); err != nil {
+            if apierrors.IsNotFound(err) {
+                continue
+            }
+            return "", fmt.Errorf("failed to cancel PipelineRun %q: %w", pr.Name, err)
+        }
+        r.Recorder.Eventf(wl, corev1.EventTypeNormal, reasonPipelineRunCancelled,
+            "Cancelled PipelineRun %s in favour of %s", pr.Name, next)
+    }
+    return "", nil
+}
@@ -0,0 +1,73 @@
+// File: controllers/workload_concurrency_test.go
+package controllers
+
+import (
+    "context"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+    corev1 "k8s.io/api/core/v1"
+    metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+    "k8s.io/apimachinery/pkg/runtime"
+    "knative.dev/pkg/apis"
+    "sigs.k8s.io/controller-runtime/pkg/client"
+
+    workloadv1alpha1 "tekton-controller/api/v1alpha1"
+    "tekton-controller/pkg/pipeline"
+)
+
+func workloadRun(name string, done bool) *pipelinev1.PipelineRun {
+    pr := &pipelinev1.PipelineRun{ObjectMeta: metav1.ObjectMeta{
+        Namespace: "test-ns",
+        Name:      name,
+        Labels:    map[string]string{pipeline.WorkloadNameParam: "app"},
+    }}
+    status := corev1.ConditionUnknown
+    if done {
+        status = corev1.ConditionTrue
+    }
+    pr.Status.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: status})
+    return pr
+}
+
+func TestApplyConcurrencyPolicy(t *testing.T) {
+    objs := []runtime.Object{
+        workloadRun("app-old", true),
+        workloadRun("app-running", false),
+        workloadRun("app-next", false),
+    }
+    other := workloadRun("other-running", false)
+    other.Labels[pipeline.WorkloadNameParam] = "other"
+    objs = append(objs, other)
+    ctx := context.Background()
+
+    testCases := []struct {
+        policy    workloadv1alpha1.ConcurrencyPolicy
+        blockedBy string
+        cancelled bool
+    }{
+        {policy: ""},
+        {policy: workloadv1alpha1.ConcurrencyAllow},
+        {policy: workloadv1alpha1.ConcurrencyForbid, blockedBy: "app-running"},
+        {policy: workloadv1alpha1.ConcurrencyReplace, cancelled: true},
+    }
+    for _, tc := range testCases {
+        t.Run(string(tc.policy), func(t *testing.T) {
+            r, _ := newTestReconciler(t, objs...)
+            wl := &workloadv1alpha1.Workload{ObjectMeta: metav1.ObjectMeta{Namespace: "test-ns", Name: "app"}}
+            wl.Spec.Build = &workloadv1alpha1.BuildSpec{ConcurrencyPolicy: tc.policy}
+
+            blockedBy, err := r.applyConcurrencyPolicy(ctx, wl, "app-next")
+            assert.NoError(t, err)
+            assert.Equal(t, tc.blockedBy, blockedBy)
+
+            for _, name := range []string{"app-running", "app-next", "other-running"} {
+                pr := &pipelinev1.PipelineRun{}
+                assert.NoError(t, r.Get(ctx, client.ObjectKey{Namespace: "test-ns", Name: name}, pr))
+                // 대상 PipelineRun 과 다른 Workload 의 실행은 취소하지 않음
+                assert.Equal(t, tc.cancelled && name == "app-running", pr.IsCancelled(), name)
+            }
+        })
+    }
+}
@@ -3,21 +3,29 @@ package controllers
 
 import (
     "context"
+    "errors"
     "fmt"
     "time"
 
+    "github.com/go-git/go-git/v5/plumbing/transport"
     gitHttp "github.com/go-git/go-git/v5/plumbing/transport/http"
     apierrors "k8s.io/apimachinery/pkg/api/errors"
-    "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+    "k8s.io/apimachinery/pkg/api/meta"
+    metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
     "k8s.io/apimachinery/pkg/runtime"
-    "k8s.io/apimachinery/pkg/runtime/schema"
+    "k8s.io/client-go/tools/record"
     ctrl "sigs.k8s.io/controller-runtime"
     "sigs.k8s.io/controller-runtime/pkg/client"
+    "sigs.k8s.io/controller-runtime/pkg/event"
+    "sigs.k8s.io/controller-runtime/pkg/handler"
     "sigs.k8s.io/controller-runtime/pkg/log"
+    "sigs.k8s.io/controller-runtime/pkg/source"
 
     corev1 "k8s.io/api/core/v1"
-    pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+    pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 
+    workloadv1alpha1 "tekton-controller/api/v1alpha1"
+    "tekton-controller/pkg/config"
     "tekton-controller/pkg/git"
     "tekton-controller/pkg/pipeline"
     "tekton-controller/pkg/util"
@@ -25,99 +33,109 @@ import (
 
 // --- Constants (Controller-specific) ---
 const (
-    pipelineName                   = "master-ci-pipeline"
-    finalizerName                  = "tekton.platform/workload.cleanup"
-    annotationBuildGitSecret       = "tekton.platform/build-git-secret"
-    annotationBuildGitToken        = "tekton.platform/build-git-token"
-    annotationBuildPVCClaim        = "tekton.platform/build-workspace-claim"
-
-    defaultGitSecretName           = "git-credentials"
-    defaultPVCClaimName            = "shared-data"
-    defaultImageRepoBase           = "my-registry.io"
-    defaultImageRepoBasePath       = "my-project"
-    requeuePermissionErrorDuration = 5 * time.Minute
-    requeueGitErrorDuration        = 30 * time.Second
-    requeueNotFoundDuration        = 10 * time.Second
-
-    buildServiceBindingsParam     = "buildServiceBindings"
-    buildServiceBindingsJSONParam = "buildServiceBindingsJson"
-)
+    finalizerName            = "tekton.platform/workload.cleanup"
+    annotationBuildGitSecret = workloadv1alpha1.AnnotationBuildGitSecret
+    annotationBuildGitToken  = workloadv1alpha1.AnnotationBuildGitToken
+    annotationBuildPVCClaim  = workloadv1alpha1.AnnotationBuildWorkspaceClaim
 
-// --- Unstructured Field Constants ---
-const (
-    specField   = "spec"
-    sourceField = "source"
-    gitField    = "git"
-    urlField    = "url"
-    refField    = "ref"
-    branchField = "branch"
-    paramsField = "params"
+    buildServiceBindingsParam     = pipeline.BuildServiceBindingsParam
+    buildServiceBindingsJSONParam = "buildServiceBindingsJson"
 )
 
 // --- Pipeline Parameter Name Constants ---
 const (
-    imageRepoAddressParam = "image-repo-address"
-    imageRepoPathParam    = "image-repo-path"
+    imageRepoAddressParam = pipeline.ImageRepoAddressParam
+    imageRepoPathParam    = pipeline.ImageRepoPathParam
     ciGitURLParam         = "ci-git-url"
     ciGitProjectNameParam = "ci-git-project-name"
     ciGitBranchParam      = "ci-git-branch"
+    ciGitRefParam         = "ci-git-ref"
     ciGitRevisionParam    = "ci-git-revision"
 )
 
-var (
-    workloadApiGroupVersion = schema.GroupVersion{Group: "tekton.platform", Version: "v1alpha1"}
-)
-
 // WorkloadReconciler reconciles a Workload object
 type WorkloadReconciler struct {
     client.Client
     Scheme      *runtime.Scheme
     GitResolver *git.Resolver
+    Recorder    record.EventRecorder
+
+    // Tekton reads and writes Pipelines and PipelineRuns in the configured tekton.dev version.
+    Tekton *pipeline.Client
+
+    // Config supplies the Pipeline name, defaults, listener settings and requeue delays.
+    // It is read on every reconcile so reloaded configuration applies immediately.
+    Config *config.Store
+
+    // PollInterval is the default interval at which each Workload's Git ref is
+    // re-resolved to pick up new commits. Zero disables polling.
+    PollInterval time.Duration
+
+    // GitEvents, if set, enqueues Workloads announced by the Git webhook receiver.
+    GitEvents <-chan event.GenericEvent
 }
 
 func (r *WorkloadReconciler) SetupWithManager(mgr ctrl.Manager) error {
-    r.GitResolver = git.NewResolver()
+    if r.GitResolver == nil {
+        r.GitResolver = git.NewResolver()
+    }
+    if r.Recorder == nil {
+        r.Recorder = mgr.GetEventRecorderFor("workload-controller")
+    }
+    // Tekton 은 --tekton-api-version 에 맞춰 main.go 에서 만들어 넘깁니다.
+    if r.Tekton == nil {
+        return errors.New("no Tekton client set")
+    }
     logger := mgr.GetLogger()
-    logger.Info("Git SHA cache TTL set", "ttl", r.GitResolver.SHACacheTTL)
-
-    return ctrl.NewControllerManagedBy(mgr).
-        For(&unstructured.Unstructured{Object: map[string]interface{}{
-            "apiVersion": fmt.Sprintf("%s/%s", workloadApiGroupVersion.Group, workloadApiGroupVersion.Version),
-            "kind":       "Workload",
-        }}).
-        Complete(r)
+    logger.Info("Git SHA cache TTL set", "ttl", r.GitResolver.SHACacheTTL, "pollInterval", r.PollInterval)
+
+    b := ctrl.NewControllerManagedBy(mgr).
+        For(&workloadv1alpha1.Workload{}).
+        Owns(r.Tekton.NewPipelineRunObject())
+    if r.GitEvents != nil {
+        b = b.WatchesRawSource(source.Channel(r.GitEvents, &handler.EnqueueRequestForObject{}))
+    }
+    return b.Complete(r)
 }
 
 //+kubebuilder:rbac:groups=tekton.platform,resources=workloads,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=tekton.platform,resources=workloads/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=tekton.platform,resources=workloads/finalizers,verbs=update
-//+kubebuilder:rbac:groups=tekton.dev,resources=pipelines;pipelineruns,verbs=get;list;watch;create
+//+kubebuilder:rbac:groups=tekton.dev,resources=pipelines,verbs=get;list;watch
+//+kubebuilder:rbac:groups=tekton.dev,resources=pipelineruns,verbs=get;list;watch;create;patch;delete
+//+kubebuilder:rbac:groups=tekton.dev,resources=taskruns,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 //+kubebuilder:rbac:groups="",resources=secrets;persistentvolumeclaims,verbs=get;list;watch
 //+kubebuilder:rbac:groups=projectcontour.io,resources=httpproxies,verbs=get;list;watch;create;update;patch;delete
 
-func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, retErr error) {
     reconcileCtx, cancel, reconcileID := util.NewReconcileContext(2 * time.Minute)
     defer cancel()
     logger := log.FromContext(reconcileCtx, "reconcileID", reconcileID)
+    cfg := r.Config.Get()
 
     // 1. Fetch Workload
-    wl := &unstructured.Unstructured{}
-    wl.SetGroupVersionKind(workloadApiGroupVersion.WithKind("Workload"))
+    wl := &workloadv1alpha1.Workload{}
     if err := r.Get(reconcileCtx, req.NamespacedName, wl); err != nil {
         if apierrors.IsNotFound(err) {
             return ctrl.Result{}, nil
         }
         if apierrors.IsForbidden(err) {
             logger.Error(err, "Forbidden to get Workload, check RBAC. Re-queueing.")
-            return ctrl.Result{RequeueAfter: requeuePermissionErrorDuration}, nil
+            return ctrl.Result{RequeueAfter: cfg.Requeue.PermissionError.Duration}, nil
         }
         return ctrl.Result{}, fmt.Errorf("failed to get workload: %w", err)
     }
-    ns, name := wl.GetNamespace(), wl.GetName()
+    ns, name := wl.Namespace, wl.Name
+    // deletion 과 finalizer 추가로 끝나는 reconcile 도 결과를 기록합니다.
+    defer func() { recordWorkloadReconcile(wl, retErr) }()
 
     // 2. Handle Deletion
     if !wl.GetDeletionTimestamp().IsZero() {
-        if err := HandleHTTPProxyListener(reconcileCtx, r.Client, wl); err != nil {
+        if err := observe(func() error {
+            return HandleHTTPProxyListener(reconcileCtx, r.Client, wl, cfg.Listener)
+        }); err != nil {
+            r.Recorder.Eventf(wl, corev1.EventTypeWarning, reasonCleanupFailed, "Failed to remove listener routing: %v", err)
             return ctrl.Result{}, fmt.Errorf("cleanup failed for HTTPProxyListener: %w", err)
         }
         if util.RemoveFinalizer(wl, finalizerName) {
@@ -137,23 +155,42 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
         return ctrl.Result{Requeue: true}, nil
     }
 
+    // 3-1. Patch status subresource on the way out
+    statusBase := wl.DeepCopy()
+    defer func() {
+        if err := patchStatus(reconcileCtx, r.Client, wl, statusBase); err != nil {
+            logger.Error(err, "Failed to patch Workload status")
+            if retErr == nil {
+                retErr = fmt.Errorf("failed to patch workload status: %w", err)
+            }
+        }
+    }()
+    if wl.Status.Phase == "" {
+        wl.Status.Phase = workloadv1alpha1.PhasePending
+    }
+
     // 4. Extract Git Info from Spec
-    src, found, _ := unstructured.NestedMap(wl.Object, specField, sourceField, gitField)
-    if !found || src == nil {
-        return ctrl.Result{}, fmt.Errorf("git source spec not found or invalid")
+    // 잘못된 spec 은 재시도해도 바뀌지 않으므로 상태에만 기록하고 spec 변경을 기다립니다.
+    // (admission webhook 이 켜져 있으면 여기까지 오지 않습니다.)
+    if wl.Spec.Source == nil || wl.Spec.Source.Git == nil {
+        r.markFailed(wl, workloadv1alpha1.ConditionSourceResolved, reasonInvalidSource, "spec.source.git is not set")
+        return ctrl.Result{}, nil
+    }
+    repoURL := wl.Spec.Source.Git.URL
+    gitRef := git.RefFromSpec(wl.Spec.Source.Git.Ref)
+    if err := gitRef.Validate(); err != nil {
+        r.markFailed(wl, workloadv1alpha1.ConditionSourceResolved, reasonInvalidSource, fmt.Sprintf("spec.source.git.ref: %v", err))
+        return ctrl.Result{}, nil
     }
-    repoURL := fmt.Sprintf("%v", src[urlField])
-    refMap, _ := src[refField].(map[string]interface{})
-    branch := fmt.Sprintf("%v", refMap[branchField])
     project := util.ExtractProjectName(repoURL)
 
     // 5. Determine Auth and Resolve Git SHA
-    var auth *gitHttp.BasicAuth
+    var auth transport.AuthMethod
     if token := util.GetAnnotationOrDefault(wl, annotationBuildGitToken, ""); token != "" {
         auth = &gitHttp.BasicAuth{Username: "oauth2", Password: token}
     } else {
         secret := &corev1.Secret{}
-        gitSecretName := util.GetAnnotationOrDefault(wl, annotationBuildGitSecret, defaultGitSecretName)
+        gitSecretName := util.GetAnnotationOrDefault(wl, annotationBuildGitSecret, cfg.Defaults.GitSecretName)
         if err := r.Get(reconcileCtx, client.ObjectKey{Namespace: ns, Name: gitSecretName}, secret); err != nil {
             if apierrors.IsNotFound(err) {
                 logger.Info("Git secret not found, proceeding without auth", "secret", gitSecretName)
@@ -162,55 +199,87 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
             }
         } else {
             var err error
-            auth, err = git.GetGitAuthFromSecret(secret)
+            auth, err = git.GetGitAuthFromSecret(secret, repoURL)
             if err != nil {
                 logger.Error(err, "Failed to parse git auth from secret, retrying", "secret", gitSecretName)
-                return ctrl.Result{RequeueAfter: requeueGitErrorDuration}, nil
+                r.markFailed(wl, workloadv1alpha1.ConditionSourceResolved, reasonSecretParseFailed,
+                    fmt.Sprintf("secret %q: %v", gitSecretName, err))
+                return ctrl.Result{RequeueAfter: cfg.Requeue.GitError.Duration}, nil
+            }
+            if auth == nil {
+                logger.Info("Git secret is annotated for another host, proceeding without auth", "secret", gitSecretName)
             }
         }
     }
 
-    sha, err := r.GitResolver.ResolveGitSHA(reconcileCtx, repoURL, branch, auth)
+    var resolved git.ResolvedRef
+    err := observeDuration(shaResolveDuration, func() (err error) {
+        resolved, err = r.GitResolver.Resolve(reconcileCtx, repoURL, gitRef, auth)
+        return err
+    })
     if err != nil {
-        logger.Error(err, "Failed to resolve Git SHA, retrying")
-        return ctrl.Result{RequeueAfter: requeueGitErrorDuration}, nil
+        logger.Error(err, "Failed to resolve Git SHA, retrying", "ref", gitRef.String())
+        r.markFailed(wl, workloadv1alpha1.ConditionSourceResolved, reasonGitResolveFailed, err.Error())
+        return ctrl.Result{RequeueAfter: cfg.Requeue.GitError.Duration}, nil
     }
-    logger.Info("Successfully resolved Git SHA", "sha", sha)
+    sha := resolved.SHA
+    logger.Info("Successfully resolved Git SHA", "ref", resolved.Name, "sha", sha)
+    wl.Status.LastResolvedRef = resolved.Name
+    wl.Status.LastCommitSHA = sha
+    setCondition(wl, workloadv1alpha1.ConditionSourceResolved, metav1.ConditionTrue, reasonResolved,
+        fmt.Sprintf("Resolved %s to %s", gitRef.String(), sha))
 
-    // 6. Fetch Pipeline Template
-    pl := &pipelinev1beta1.Pipeline{}
-    if err := r.Get(reconcileCtx, client.ObjectKey{Namespace: ns, Name: pipelineName}, pl); err != nil {
-        if apierrors.IsNotFound(err) {
-            logger.Error(err, "Pipeline template not found, re-queueing", "pipelineName", pipelineName)
-            return ctrl.Result{RequeueAfter: requeueNotFoundDuration}, nil
+    // 6. Fetch Pipeline Template (spec.pipelineRef or the configured Pipeline)
+    selected, err := selectPipeline(wl, cfg)
+    if err != nil {
+        r.markFailed(wl, workloadv1alpha1.ConditionPipelineRunCreated, reasonInvalidPipelineRef, err.Error())
+        return ctrl.Result{}, nil
+    }
+    // 리졸버 참조는 로컬 Pipeline 을 읽지 않고, 마지막 실행에서 풀린 spec 으로 검증합니다.
+    var plSpec *pipelinev1.PipelineSpec
+    wsDecls := selected.Workspaces
+    if selected.Key != nil {
+        pl, err := r.Tekton.GetPipeline(reconcileCtx, *selected.Key)
+        if err != nil {
+            if apierrors.IsNotFound(err) {
+                logger.Error(err, "Pipeline template not found, re-queueing", "pipeline", selected.Key)
+                r.markFailed(wl, workloadv1alpha1.ConditionPipelineRunCreated, reasonPipelineTemplateMissing,
+                    fmt.Sprintf("Pipeline %q not found in namespace %q", selected.Key.Name, selected.Key.Namespace))
+                return ctrl.Result{RequeueAfter: cfg.Requeue.NotFound.Duration}, nil
+            }
+            return ctrl.Result{}, fmt.Errorf("failed to get Pipeline template: %w", err)
         }
-        return ctrl.Result{}, fmt.Errorf("failed to get Pipeline template: %w", err)
+        plSpec, wsDecls = &pl.Spec, pl.Spec.Workspaces
+    } else if plSpec, err = r.resolvedPipelineSpec(reconcileCtx, wl, selected.Ref); err != nil {
+        return ctrl.Result{}, err
     }
 
     // 7. Build PipelineRun params map
-    rawParams, _, _ := unstructured.NestedSlice(wl.Object, specField, paramsField)
-    paramsMap := pipeline.ParamMapFromSpec(rawParams)
-    if paramsMap[imageRepoAddressParam] == "" {
-        paramsMap[imageRepoAddressParam] = defaultImageRepoBase
-    }
-    if paramsMap[imageRepoPathParam] == "" {
-        paramsMap[imageRepoPathParam] = defaultImageRepoBasePath
+    paramsMap := pipeline.ParamMapFromSpec(wl.Spec.Params)
+    for _, p := range []struct{ name, value string }{
+        {imageRepoAddressParam, cfg.Defaults.ImageRepoAddress},
+        {imageRepoPathParam, cfg.Defaults.ImageRepoPath},
+    } {
+        if v, ok := paramsMap[p.name]; !ok || (v.Type == pipelinev1.ParamTypeString && v.StringVal == "") {
+            paramsMap[p.name] = *pipelinev1.NewStructuredValues(p.value)
+        }
     }
     defaults := map[string]string{
         ciGitURLParam:            repoURL,
         ciGitProjectNameParam:    project,
-        ciGitBranchParam:         branch,
+        ciGitBranchParam:         resolved.ShortName(),
+        ciGitRefParam:            resolved.Name,
         ciGitRevisionParam:       sha,
         pipeline.WorkloadNameParam: name,
     }
     for k, v := range defaults {
         if _, ok := paramsMap[k]; !ok {
-            paramsMap[k] = v
+            paramsMap[k] = *pipelinev1.NewStructuredValues(v)
         }
     }
 
     // 7-1. Extract & JSON-marshal ServiceBindings
-    sbList, err := pipeline.ExtractServiceBindings(rawParams, buildServiceBindingsParam)
+    sbList, err := pipeline.ExtractServiceBindings(wl.Spec.Params, buildServiceBindingsParam)
     if err != nil {
         return ctrl.Result{}, fmt.Errorf("failed to extract serviceBindings: %w", err)
     }
@@ -219,32 +288,175 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
         if err != nil {
             return ctrl.Result{}, fmt.Errorf("failed to marshal serviceBindings: %w", err)
         }
-        paramsMap[buildServiceBindingsJSONParam] = sbJSON
+        paramsMap[buildServiceBindingsJSONParam] = *pipelinev1.NewStructuredValues(sbJSON)
     }
 
     // 8. Build workspace bindings (PVC + Secrets + service-bindings)
-    pvcClaim := util.GetAnnotationOrDefault(wl, annotationBuildPVCClaim, defaultPVCClaimName)
+    pvcClaim := util.GetAnnotationOrDefault(wl, annotationBuildPVCClaim, cfg.Defaults.WorkspaceClaimName)
     wsBindings, err := pipeline.AppendServiceBindingWorkspaces(
-        reconcileCtx, r.Client, ns, pl.Spec.Workspaces, pvcClaim, sbList,
+        reconcileCtx, r.Client, ns, wsDecls, pvcClaim, sbList,
     )
     if err != nil {
         return ctrl.Result{}, fmt.Errorf("failed to build workspaces: %w", err)
     }
 
-    // 9. Create PipelineRun
-    prPrefix := fmt.Sprintf("%s-%s", name, sha[:7])
+    // 8-1. Check the Pipeline's required workspaces can be bound (params are reconciled in 9)
+    if plSpec != nil {
+        if err := pipeline.CheckCompatible(plSpec, wsBindings); err != nil {
+            r.markFailed(wl, workloadv1alpha1.ConditionPipelineRunCreated, reasonPipelineIncompatible,
+                fmt.Sprintf("Pipeline %s: %v", selected, err))
+            return ctrl.Result{RequeueAfter: cfg.Requeue.NotFound.Duration}, nil
+        }
+    }
+
+    // 8-2. Apply spec.resources to the tasks of Pipelines the controller reads
+    computeResources, err := pipeline.ComputeResources(wl.Spec.Resources)
+    if err != nil {
+        r.markFailed(wl, workloadv1alpha1.ConditionPipelineRunCreated, reasonInvalidResources, err.Error())
+        return ctrl.Result{}, nil
+    }
+    var taskRunSpecs []pipelinev1.PipelineTaskRunSpec
+    if computeResources != nil {
+        if selected.Key != nil {
+            taskRunSpecs = pipeline.TaskRunSpecs(plSpec, computeResources)
+        } else {
+            // 리졸버 Pipeline 은 task 이름을 알 수 없어 적용하지 않습니다.
+            logger.V(1).Info("Skipping spec.resources for resolver Pipeline", "pipeline", selected.String())
+        }
+    }
+
+    // 9. Create PipelineRun (only when the commit or build inputs changed)
     params := pipeline.BuildPipelineRunParams(paramsMap)
-    pr := pipeline.NewPipelineRun(wl, ns, prPrefix, pipelineName, params, wsBindings)
-    if err := r.Create(reconcileCtx, pr); err != nil && !apierrors.IsAlreadyExists(err) {
-        return ctrl.Result{}, fmt.Errorf("failed to create PipelineRun: %w", err)
+    var buildEnv []workloadv1alpha1.EnvVar
+    if wl.Spec.Build != nil {
+        buildEnv = wl.Spec.Build.Env
+    }
+    params = pipeline.AddEnvParam(params, pipeline.BuildEnvParam, buildEnv)
+    params = pipeline.AddEnvParam(params, pipeline.AppEnvParam, wl.Spec.Env)
+    // 해시는 Pipeline 스펙과 맞추기 전의 params 로 계산합니다. resolver Pipeline 은 첫 실행 뒤에야
+    // 스펙을 알 수 있으므로, 맞춘 결과로 계산하면 같은 커밋에 PipelineRun 이 다시 만들어집니다.
+    buildHash, err := pipeline.BuildInputsHash(selected.ID, params, wsBindings, taskRunSpecs)
+    if err != nil {
+        return ctrl.Result{}, fmt.Errorf("failed to hash build inputs: %w", err)
+    }
+    if plSpec != nil {
+        // 선언되지 않은 params 는 별칭으로 바꾸거나 제외하고, 기본값을 채웁니다.
+        var ignored []string
+        params, ignored, err = pipeline.ReconcileParams(plSpec.Params, params, cfg.ParamAliases)
+        r.setIgnoredParams(wl, selected, ignored)
+        if err != nil {
+            r.markFailed(wl, workloadv1alpha1.ConditionPipelineRunCreated, reasonMissingParams,
+                fmt.Sprintf("Pipeline %s: %v", selected, err))
+            return ctrl.Result{RequeueAfter: cfg.Requeue.NotFound.Duration}, nil
+        }
+        // 선언된 ParamSpec.Type 과 값의 타입을 맞춥니다.
+        if params, err = pipeline.CheckParamTypes(plSpec.Params, params); err != nil {
+            r.markFailed(wl, workloadv1alpha1.ConditionPipelineRunCreated, reasonPipelineIncompatible,
+                fmt.Sprintf("Pipeline %s: %v", selected, err))
+            return ctrl.Result{RequeueAfter: cfg.Requeue.NotFound.Duration}, nil
+        }
+    } else {
+        r.setIgnoredParams(wl, selected, nil)
+    }
+    upToDate := wl.Status.LastAppliedRevision == sha && wl.Status.LastBuildHash == buildHash
+    prName := pipeline.PipelineRunName(name, sha, buildHash)
+    var blockedBy string
+    if !upToDate {
+        // 9-1. Apply spec.build.concurrencyPolicy to runs still using the shared workspace
+        if blockedBy, err = r.applyConcurrencyPolicy(reconcileCtx, wl, prName); err != nil {
+            return ctrl.Result{}, err
+        }
+    }
+    switch {
+    case upToDate:
+        logger.Info("Build inputs unchanged, skipping PipelineRun creation",
+            "sha", sha, "buildHash", buildHash, "pipelineRun", wl.Status.LastPipelineRunName)
+        setCondition(wl, workloadv1alpha1.ConditionPipelineRunCreated, metav1.ConditionTrue, reasonPipelineRunUpToDate,
+            fmt.Sprintf("PipelineRun %q already built %s", wl.Status.LastPipelineRunName, sha))
+    case blockedBy != "":
+        // Forbid: 실행 중인 PipelineRun 이 끝나면 Owns() watch 로 다시 reconcile 됩니다.
+        logger.Info("Waiting for running PipelineRun to finish", "running", blockedBy, "sha", sha)
+        queuedMsg := fmt.Sprintf("Waiting for PipelineRun %q to finish before building %s", blockedBy, sha)
+        cond := meta.FindStatusCondition(wl.Status.Conditions, workloadv1alpha1.ConditionPipelineRunCreated)
+        if cond == nil || cond.Message != queuedMsg {
+            r.Recorder.Event(wl, corev1.EventTypeNormal, reasonPipelineRunQueued, queuedMsg)
+        }
+        setCondition(wl, workloadv1alpha1.ConditionPipelineRunCreated, metav1.ConditionFalse, reasonPipelineRunQueued, queuedMsg)
+    default:
+        pr := pipeline.NewPipelineRun(wl, ns, prName, selected.Ref, params, wsBindings)
+        pr.Labels[pipeline.CommitSHALabel] = sha
+        pr.Labels[pipeline.BuildHashLabel] = buildHash
+        pr.Spec.TaskRunSpecs = taskRunSpecs
+        if timeout := cfg.PipelineRunTimeout; timeout.Duration > 0 {
+            pr.Spec.Timeouts = &pipelinev1.TimeoutFields{Pipeline: &timeout}
+        }
+        if err := r.Tekton.CreatePipelineRun(reconcileCtx, pr); err != nil {
+            if !apierrors.IsAlreadyExists(err) {
+                r.markFailed(wl, workloadv1alpha1.ConditionPipelineRunCreated, reasonPipelineRunFailed, err.Error())
+                return ctrl.Result{}, fmt.Errorf("failed to create PipelineRun: %w", err)
+            }
+            logger.Info("PipelineRun already exists for build inputs", "pipelineRun", pr.Name)
+        } else {
+            logger.Info("Created PipelineRun", "pipelineRun", pr.Name, "sha", sha)
+            pipelineRunsCreated.WithLabelValues(ns).Inc()
+            r.Recorder.Eventf(wl, corev1.EventTypeNormal, reasonPipelineRunCreated, "Created PipelineRun %s for %s", pr.Name, sha)
+        }
+        wl.Status.LastPipelineRunName = pr.Name
+        wl.Status.LastAppliedRevision = sha
+        wl.Status.LastBuildHash = buildHash
+        setCondition(wl, workloadv1alpha1.ConditionPipelineRunCreated, metav1.ConditionTrue, reasonPipelineRunCreated,
+            fmt.Sprintf("PipelineRun %q created for %s", pr.Name, sha))
+    }
+
+    // 9-2. Mirror the latest PipelineRun into status
+    if err := r.syncPipelineRunStatus(reconcileCtx, wl); err != nil {
+        return ctrl.Result{}, err
+    }
+
+    // 9-3. Prune completed PipelineRuns beyond spec.build.history
+    if err := r.pruneHistory(reconcileCtx, wl, cfg.History); err != nil {
+        return ctrl.Result{}, err
     }
 
     // 10. Handle HTTPProxy listener
-    if err := HandleHTTPProxyListener(reconcileCtx, r.Client, wl); err != nil {
+    if err := observe(func() error {
+        return HandleHTTPProxyListener(reconcileCtx, r.Client, wl, cfg.Listener)
+    }); err != nil {
+        r.markFailed(wl, workloadv1alpha1.ConditionRoutingReady, reasonRoutingFailed, err.Error())
         return ctrl.Result{}, fmt.Errorf("failed to handle HTTPProxy: %w", err)
     }
+    routedMsg := fmt.Sprintf("Listener %s-listener included in global HTTPProxy", ns)
+    if !meta.IsStatusConditionTrue(wl.Status.Conditions, workloadv1alpha1.ConditionRoutingReady) {
+        r.Recorder.Event(wl, corev1.EventTypeNormal, reasonListenerRouted, routedMsg)
+    }
+    setCondition(wl, workloadv1alpha1.ConditionRoutingReady, metav1.ConditionTrue, reasonListenerRouted, routedMsg)
+
+    markReady(wl)
+    requeueAfter := r.pollInterval(wl)
+    logger.Info("Reconciliation complete", "nextPoll", requeueAfter)
+    return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// markFailed marks the Workload failed and records a Warning event with the same reason.
+func (r *WorkloadReconciler) markFailed(wl *workloadv1alpha1.Workload, condType, reason, message string) {
+    markFailed(wl, condType, reason, message)
+    r.Recorder.Event(wl, corev1.EventTypeWarning, reason, message)
+}
 
-    logger.Info("Reconciliation complete")
-    return ctrl.Result{}, nil
+// pollInterval returns how long to wait before re-resolving the Workload's Git ref.
+// Intervals shorter than the SHA cache TTL are raised to the TTL, since polling faster
+// would only hit the cache; this also lets Workloads on the same repo share one fetch.
+func (r *WorkloadReconciler) pollInterval(wl *workloadv1alpha1.Workload) time.Duration {
+    interval := r.PollInterval
+    if wl.Spec.Source != nil && wl.Spec.Source.Git != nil && wl.Spec.Source.Git.PollInterval != nil {
+        interval = wl.Spec.Source.Git.PollInterval.Duration
+    }
+    if interval <= 0 {
+        return 0
+    }
+    if ttl := r.GitResolver.SHACacheTTL; interval < ttl {
+        interval = ttl
+    }
+    return interval
 }
 
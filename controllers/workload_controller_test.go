@@ -0,0 +1,48 @@
+// File: controllers/workload_controller_test.go
+package controllers
+
+import (
+    "testing"
+    "time"
+
+    "github.com/stretchr/testify/assert"
+    metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+    "k8s.io/client-go/tools/record"
+
+    workloadv1alpha1 "tekton-controller/api/v1alpha1"
+    "tekton-controller/pkg/git"
+)
+
+func TestPollInterval(t *testing.T) {
+    r := &WorkloadReconciler{
+        GitResolver:  &git.Resolver{SHACacheTTL: time.Minute},
+        PollInterval: 5 * time.Minute,
+    }
+    wl := &workloadv1alpha1.Workload{}
+    wl.Spec.Source = &workloadv1alpha1.Source{Git: &workloadv1alpha1.GitSource{URL: "https://gitlab.com/a/b.git"}}
+
+    // 전역 기본값 사용
+    assert.Equal(t, 5*time.Minute, r.pollInterval(wl))
+
+    // Workload 별 override
+    wl.Spec.Source.Git.PollInterval = &metav1.Duration{Duration: 2 * time.Minute}
+    assert.Equal(t, 2*time.Minute, r.pollInterval(wl))
+
+    // 캐시 TTL 보다 짧으면 TTL 로 올림
+    wl.Spec.Source.Git.PollInterval = &metav1.Duration{Duration: 10 * time.Second}
+    assert.Equal(t, time.Minute, r.pollInterval(wl))
+
+    // 0 이면 polling 비활성화
+    wl.Spec.Source.Git.PollInterval = &metav1.Duration{}
+    assert.Equal(t, time.Duration(0), r.pollInterval(wl))
+}
+
+func TestMarkFailedRecordsEvent(t *testing.T) {
+    recorder := record.NewFakeRecorder(1)
+    r := &WorkloadReconciler{Recorder: recorder}
+    wl := &workloadv1alpha1.Workload{}
+
+    r.markFailed(wl, workloadv1alpha1.ConditionSourceResolved, reasonGitResolveFailed, "repository not found")
+    assert.Equal(t, workloadv1alpha1.PhaseFailed, wl.Status.Phase)
+    assert.Equal(t, "Warning GitResolveFailed repository not found", <-recorder.Events)
+}
@@ -0,0 +1,96 @@
+// File: controllers/workload_history.go
+package controllers
+
+import (
+    "context"
+    "fmt"
+    "sort"
+
+    pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+    apierrors "k8s.io/apimachinery/pkg/api/errors"
+    metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+    "knative.dev/pkg/apis"
+    "sigs.k8s.io/controller-runtime/pkg/client"
+    "sigs.k8s.io/controller-runtime/pkg/log"
+
+    workloadv1alpha1 "tekton-controller/api/v1alpha1"
+    "tekton-controller/pkg/config"
+)
+
+// historyLimits returns the succeeded and failed PipelineRun limits for wl,
+// falling back to the controller defaults for unset values.
+func historyLimits(wl *workloadv1alpha1.Workload, defaults config.History) (succeeded, failed int) {
+    succeeded, failed = int(defaults.SuccessfulRunsLimit), int(defaults.FailedRunsLimit)
+    if wl.Spec.Build == nil || wl.Spec.Build.History == nil {
+        return succeeded, failed
+    }
+    if l := wl.Spec.Build.History.SuccessfulRunsLimit; l != nil {
+        succeeded = int(*l)
+    }
+    if l := wl.Spec.Build.History.FailedRunsLimit; l != nil {
+        failed = int(*l)
+    }
+    return succeeded, failed
+}
+
+// pruneHistory deletes the oldest completed PipelineRuns of wl beyond the history
+// limits. Running PipelineRuns and the one in status.lastPipelineRunName are kept.
+func (r *WorkloadReconciler) pruneHistory(ctx context.Context, wl *workloadv1alpha1.Workload, defaults config.History) error {
+    logger := log.FromContext(ctx)
+    runs, err := r.listWorkloadPipelineRuns(ctx, wl)
+    if err != nil {
+        return err
+    }
+
+    var succeeded, failed []*pipelinev1.PipelineRun
+    for i := range runs {
+        pr := &runs[i]
+        if !pr.IsDone() || pr.Name == wl.Status.LastPipelineRunName {
+            continue
+        }
+        if pr.Status.GetCondition(apis.ConditionSucceeded).IsTrue() {
+            succeeded = append(succeeded, pr)
+        } else {
+            failed = append(failed, pr)
+        }
+    }
+
+    succeededLimit, failedLimit := historyLimits(wl, defaults)
+    for _, group := range []struct {
+        runs  []*pipelinev1.PipelineRun
+        limit int
+    }{{succeeded, succeededLimit}, {failed, failedLimit}} {
+        if len(group.runs) <= group.limit {
+            continue
+        }
+        sortNewestFirst(group.runs)
+        for _, pr := range group.runs[group.limit:] {
+            if err := r.Tekton.DeletePipelineRun(ctx, pr, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil {
+                if apierrors.IsNotFound(err) {
+                    continue
+                }
+                return fmt.Errorf("failed to delete PipelineRun %q: %w", pr.Name, err)
+            }
+            logger.Info("Pruned PipelineRun", "pipelineRun", pr.Name)
+            pipelineRunsPruned.WithLabelValues(wl.Namespace).Inc()
+        }
+    }
+    return nil
+}
+
+// sortNewestFirst orders PipelineRuns by completion time, then creation time, newest first.
+func sortNewestFirst(runs []*pipelinev1.PipelineRun) {
+    finished := func(pr *pipelinev1.PipelineRun) metav1.Time {
+        if pr.Status.CompletionTime != nil {
+            return *pr.Status.CompletionTime
+        }
+        return pr.CreationTimestamp
+    }
+    sort.SliceStable(runs, func(i, j int) bool {
+        ti, tj := finished(runs[i]), finished(runs[j])
+        if !ti.Equal(&tj) {
+            return tj.Before(&ti)
+        }
+        return runs[i].Name > runs[j].Name
+    })
+}
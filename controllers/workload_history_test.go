@@ -0,0 +1,64 @@
+// File: controllers/workload_history_test.go
+package controllers
+
+import (
+    "context"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+    corev1 "k8s.io/api/core/v1"
+    metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+    "k8s.io/apimachinery/pkg/runtime"
+    "k8s.io/utils/ptr"
+    "knative.dev/pkg/apis"
+
+    workloadv1alpha1 "tekton-controller/api/v1alpha1"
+    "tekton-controller/pkg/config"
+)
+
+func finishedRun(name string, status corev1.ConditionStatus, completedAt int64) *pipelinev1.PipelineRun {
+    pr := workloadRun(name, false)
+    pr.Status.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: status})
+    t := metav1.Unix(completedAt, 0)
+    pr.Status.CompletionTime = &t
+    return pr
+}
+
+func TestPruneHistory(t *testing.T) {
+    objs := []runtime.Object{
+        finishedRun("ok-1", corev1.ConditionTrue, 100),
+        finishedRun("ok-2", corev1.ConditionTrue, 200),
+        finishedRun("ok-3", corev1.ConditionTrue, 300),
+        finishedRun("fail-1", corev1.ConditionFalse, 150),
+        finishedRun("fail-2", corev1.ConditionFalse, 250),
+        workloadRun("running", false),
+    }
+    r, _ := newTestReconciler(t, objs...)
+    ctx := context.Background()
+    wl := &workloadv1alpha1.Workload{ObjectMeta: metav1.ObjectMeta{Namespace: "test-ns", Name: "app"}}
+    // status 가 가리키는 실행은 오래되어도 남김
+    wl.Status.LastPipelineRunName = "ok-1"
+    wl.Spec.Build = &workloadv1alpha1.BuildSpec{History: &workloadv1alpha1.BuildHistory{SuccessfulRunsLimit: ptr.To[int32](1)}}
+
+    assert.NoError(t, r.pruneHistory(ctx, wl, config.History{SuccessfulRunsLimit: 5, FailedRunsLimit: 0}))
+
+    runs, err := r.listWorkloadPipelineRuns(ctx, wl)
+    assert.NoError(t, err)
+    var names []string
+    for _, pr := range runs {
+        names = append(names, pr.Name)
+    }
+    assert.ElementsMatch(t, []string{"ok-1", "ok-3", "running"}, names)
+}
+
+func TestHistoryLimits(t *testing.T) {
+    defaults := config.History{SuccessfulRunsLimit: 5, FailedRunsLimit: 3}
+    wl := &workloadv1alpha1.Workload{}
+    s, f := historyLimits(wl, defaults)
+    assert.Equal(t, []int{5, 3}, []int{s, f})
+
+    wl.Spec.Build = &workloadv1alpha1.BuildSpec{History: &workloadv1alpha1.BuildHistory{FailedRunsLimit: ptr.To[int32](0)}}
+    s, f = historyLimits(wl, defaults)
+    assert.Equal(t, []int{5, 0}, []int{s, f})
+}
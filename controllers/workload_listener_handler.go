@@ -12,6 +12,9 @@ import (
     "k8s.io/apimachinery/pkg/util/wait"
     "sigs.k8s.io/controller-runtime/pkg/client"
     ctrlLog "sigs.k8s.io/controller-runtime/pkg/log"
+
+    workloadv1alpha1 "tekton-controller/api/v1alpha1"
+    "tekton-controller/pkg/config"
 )
 
 const (
@@ -19,13 +22,10 @@ const (
     httpProxyVersion = "v1"
     httpProxyKind    = "HTTPProxy"
 
-    globalProxyNS   = "argocd"
-    globalProxyName = "proxy-to-listener"
-
     maxRetries = 5
 )
 
-func HandleHTTPProxyListener(ctx context.Context, c client.Client, workload *unstructured.Unstructured) error {
+func HandleHTTPProxyListener(ctx context.Context, c client.Client, workload *workloadv1alpha1.Workload, cfg config.Listener) error {
     logger := ctrlLog.FromContext(ctx)
     ns := workload.GetNamespace()
     listenerName := fmt.Sprintf("%s-listener", ns)
@@ -41,7 +41,7 @@ func HandleHTTPProxyListener(ctx context.Context, c client.Client, workload *uns
         }
 
         if err := retry(func() error {
-            return removeGlobalProxyInclude(ctx, c, listenerName, ns)
+            return removeGlobalProxyInclude(ctx, c, cfg, listenerName, ns)
         }); err != nil {
             logger.Error(err, "Failed to remove include from global HTTPProxy")
             return err
@@ -53,18 +53,18 @@ func HandleHTTPProxyListener(ctx context.Context, c client.Client, workload *uns
 
     svcName := workload.GetAnnotations()["listenerService"]
     if svcName == "" {
-        svcName = "el-simple-listener"
+        svcName = cfg.ServiceName
     }
 
     if err := retry(func() error {
-        return ensureListener(ctx, c, listenerName, ns, svcName)
+        return ensureListener(ctx, c, listenerName, ns, svcName, cfg.Port)
     }); err != nil {
         logger.Error(err, "Failed to ensure listener HTTPProxy")
         return err
     }
 
     if err := retry(func() error {
-        return updateGlobalProxyIncludes(ctx, c, listenerName, ns)
+        return updateGlobalProxyIncludes(ctx, c, cfg, listenerName, ns)
     }); err != nil {
         logger.Error(err, "Failed to update include in global HTTPProxy")
         return err
@@ -107,7 +107,7 @@ func deleteListener(ctx context.Context, c client.Client, listenerName, ns strin
     return nil
 }
 
-func ensureListener(ctx context.Context, c client.Client, listenerName, ns, svcName string) error {
+func ensureListener(ctx context.Context, c client.Client, listenerName, ns, svcName string, port int64) error {
     logger := ctrlLog.FromContext(ctx)
     proxy := &unstructured.Unstructured{}
     proxy.SetGroupVersionKind(schema.GroupVersionKind{
@@ -135,7 +135,7 @@ func ensureListener(ctx context.Context, c client.Client, listenerName, ns, svcN
                         "services": []interface{}{
                             map[string]interface{}{
                                 "name": svcName,
-                                "port": int64(8080),
+                                "port": port,
                             },
                         },
                     },
@@ -152,7 +152,7 @@ func ensureListener(ctx context.Context, c client.Client, listenerName, ns, svcN
     return nil
 }
 
-func removeGlobalProxyInclude(ctx context.Context, c client.Client, listenerName, ns string) error {
+func removeGlobalProxyInclude(ctx context.Context, c client.Client, cfg config.Listener, listenerName, ns string) error {
     logger := ctrlLog.FromContext(ctx)
     gp := &unstructured.Unstructured{}
     gp.SetGroupVersionKind(schema.GroupVersionKind{
@@ -161,7 +161,7 @@ func removeGlobalProxyInclude(ctx context.Context, c client.Client, listenerName
         Kind:    httpProxyKind,
     })
 
-    if err := c.Get(ctx, client.ObjectKey{Namespace: globalProxyNS, Name: globalProxyName}, gp); err != nil {
+    if err := c.Get(ctx, client.ObjectKey{Namespace: cfg.GlobalProxyNamespace, Name: cfg.GlobalProxyName}, gp); err != nil {
         logger.Info("🌐 Global HTTPProxy not found, skip include removal")
         return nil
     }
@@ -192,7 +192,9 @@ func removeGlobalProxyInclude(ctx context.Context, c client.Client, listenerName
     if err := unstructured.SetNestedSlice(gp.Object, newIncs, "spec", "includes"); err != nil {
         return fmt.Errorf("set includes on global proxy: %w", err)
     }
-    if err := c.Update(ctx, gp); err != nil {
+    err = c.Update(ctx, gp)
+    httpProxyIncludeOps.WithLabelValues("remove", resultLabel(err)).Inc()
+    if err != nil {
         return fmt.Errorf("update global proxy after include removal: %w", err)
     }
 
@@ -200,7 +202,7 @@ func removeGlobalProxyInclude(ctx context.Context, c client.Client, listenerName
     return nil
 }
 
-func updateGlobalProxyIncludes(ctx context.Context, c client.Client, listenerName, ns string) error {
+func updateGlobalProxyIncludes(ctx context.Context, c client.Client, cfg config.Listener, listenerName, ns string) error {
     logger := ctrlLog.FromContext(ctx)
     gp := &unstructured.Unstructured{}
     gp.SetGroupVersionKind(schema.GroupVersionKind{
@@ -209,7 +211,7 @@ func updateGlobalProxyIncludes(ctx context.Context, c client.Client, listenerNam
         Kind:    httpProxyKind,
     })
 
-    if err := c.Get(ctx, client.ObjectKey{Namespace: globalProxyNS, Name: globalProxyName}, gp); err != nil {
+    if err := c.Get(ctx, client.ObjectKey{Namespace: cfg.GlobalProxyNamespace, Name: cfg.GlobalProxyName}, gp); err != nil {
         return nil
     }
 
@@ -258,7 +260,9 @@ func updateGlobalProxyIncludes(ctx context.Context, c client.Client, listenerNam
     if err := unstructured.SetNestedSlice(gp.Object, filtered, "spec", "includes"); err != nil {
         return fmt.Errorf("set includes on global proxy: %w", err)
     }
-    if err := c.Update(ctx, gp); err != nil {
+    err := c.Update(ctx, gp)
+    httpProxyIncludeOps.WithLabelValues("add", resultLabel(err)).Inc()
+    if err != nil {
         return fmt.Errorf("update global proxy: %w", err)
     }
 
@@ -11,15 +11,15 @@ import (
     "k8s.io/apimachinery/pkg/runtime/schema"
     "sigs.k8s.io/controller-runtime/pkg/client"
     "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+    workloadv1alpha1 "tekton-controller/api/v1alpha1"
+    "tekton-controller/pkg/config"
 )
 
 func setupScheme() *runtime.Scheme {
     scheme := runtime.NewScheme()
     // 1) Workload CRD
-    scheme.AddKnownTypeWithName(
-        schema.GroupVersionKind{Group: "tekton.platform", Version: "v1alpha1", Kind: "Workload"},
-        &unstructured.Unstructured{},
-    )
+    _ = workloadv1alpha1.AddToScheme(scheme)
     // 2) HTTPProxy CRD
     scheme.AddKnownTypeWithName(
         schema.GroupVersionKind{Group: httpProxyGroup, Version: httpProxyVersion, Kind: httpProxyKind},
@@ -34,17 +34,14 @@ func TestHandleHTTPProxyListener_CreatesListener(t *testing.T) {
     ctx := context.Background()
 
     // --- workload 객체를 fake client 에 미리 저장 ---
-    wl := &unstructured.Unstructured{}
-    wl.SetGroupVersionKind(schema.GroupVersionKind{
-        Group:   "tekton.platform", Version: "v1alpha1", Kind: "Workload",
-    })
+    wl := &workloadv1alpha1.Workload{}
     wl.SetNamespace("test-ns")
     wl.SetName("test-wl")
     wl.SetAnnotations(map[string]string{"listenerService": "my-svc"})
     assert.NoError(t, cli.Create(ctx, wl))
 
     // --- 실제 핸들러 호출 ---
-    err := HandleHTTPProxyListener(ctx, cli, wl)
+    err := HandleHTTPProxyListener(ctx, cli, wl, config.Default().Listener)
     assert.NoError(t, err)
 
     // --- listener HTTPProxy 생성 확인 ---
@@ -60,16 +57,41 @@ func TestHandleHTTPProxyListener_CreatesListener(t *testing.T) {
     assert.Len(t, routes, 1, "routes 배열 길이는 1이어야 합니다")
 }
 
+func TestHandleHTTPProxyListener_UsesConfiguredListener(t *testing.T) {
+    cli := fake.NewClientBuilder().WithScheme(setupScheme()).Build()
+    ctx := context.Background()
+
+    wl := &workloadv1alpha1.Workload{}
+    wl.SetNamespace("test-ns")
+    wl.SetName("test-wl")
+
+    cfg := config.Default().Listener
+    cfg.ServiceName = "el-custom"
+    cfg.Port = 9000
+    assert.NoError(t, HandleHTTPProxyListener(ctx, cli, wl, cfg))
+
+    proxy := &unstructured.Unstructured{}
+    proxy.SetGroupVersionKind(schema.GroupVersionKind{
+        Group: httpProxyGroup, Version: httpProxyVersion, Kind: httpProxyKind,
+    })
+    assert.NoError(t, cli.Get(ctx, client.ObjectKey{Namespace: "test-ns", Name: "test-ns-listener"}, proxy))
+    routes, _, _ := unstructured.NestedSlice(proxy.Object, "spec", "routes")
+    svc := routes[0].(map[string]interface{})["services"].([]interface{})[0].(map[string]interface{})
+    assert.Equal(t, "el-custom", svc["name"])
+    assert.Equal(t, int64(9000), svc["port"])
+}
+
 func TestRemoveGlobalProxyInclude_RemovesElement(t *testing.T) {
     scheme := setupScheme()
+    cfg := config.Default().Listener
 
     // 글로벌 proxy 객체 준비
     gp := &unstructured.Unstructured{}
     gp.SetGroupVersionKind(schema.GroupVersionKind{
         Group:   httpProxyGroup, Version: httpProxyVersion, Kind: httpProxyKind,
     })
-    gp.SetNamespace(globalProxyNS)
-    gp.SetName(globalProxyName)
+    gp.SetNamespace(cfg.GlobalProxyNamespace)
+    gp.SetName(cfg.GlobalProxyName)
     // spec.includes 에 테스트 항목 삽입
     _ = unstructured.SetNestedSlice(
         gp.Object,
@@ -86,7 +108,7 @@ func TestRemoveGlobalProxyInclude_RemovesElement(t *testing.T) {
     ctx := context.Background()
 
     // include 제거 호출
-    err := removeGlobalProxyInclude(ctx, cli, "test-ns-listener", "test-ns")
+    err := removeGlobalProxyInclude(ctx, cli, cfg, "test-ns-listener", "test-ns")
     assert.NoError(t, err)
 
     // 결과 확인
@@ -94,9 +116,9 @@ func TestRemoveGlobalProxyInclude_RemovesElement(t *testing.T) {
     updated.SetGroupVersionKind(schema.GroupVersionKind{
         Group:   httpProxyGroup, Version: httpProxyVersion, Kind: httpProxyKind,
     })
-    updated.SetNamespace(globalProxyNS)
-    updated.SetName(globalProxyName)
-    err = cli.Get(ctx, client.ObjectKey{Namespace: globalProxyNS, Name: globalProxyName}, updated)
+    updated.SetNamespace(cfg.GlobalProxyNamespace)
+    updated.SetName(cfg.GlobalProxyName)
+    err = cli.Get(ctx, client.ObjectKey{Namespace: cfg.GlobalProxyNamespace, Name: cfg.GlobalProxyName}, updated)
     assert.NoError(t, err)
 
     inc, found, _ := unstructured.NestedSlice(updated.Object, "spec", "includes")
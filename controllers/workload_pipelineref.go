@@ -0,0 +1,133 @@
+// File: controllers/workload_pipelineref.go
+package controllers
+
+import (
+    "context"
+    "fmt"
+    "slices"
+    "strings"
+
+    pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+    corev1 "k8s.io/api/core/v1"
+    "k8s.io/apimachinery/pkg/api/equality"
+    apierrors "k8s.io/apimachinery/pkg/api/errors"
+    "sigs.k8s.io/controller-runtime/pkg/client"
+
+    workloadv1alpha1 "tekton-controller/api/v1alpha1"
+    "tekton-controller/pkg/config"
+    "tekton-controller/pkg/pipeline"
+)
+
+// pipelineSelection is the Pipeline a Workload runs.
+type pipelineSelection struct {
+    // Key locates the Pipeline for validation. It is nil for resolver references,
+    // whose Pipeline the controller does not read.
+    Key *client.ObjectKey
+    // Ref is set as the PipelineRun's spec.pipelineRef.
+    Ref *pipelinev1.PipelineRef
+    // ID identifies the Pipeline in the build inputs hash. For namespace-local
+    // Pipelines it is the plain name, so existing hashes stay valid.
+    ID string
+    // Workspaces declares the workspaces of a resolver-referenced Pipeline.
+    Workspaces []pipelinev1.PipelineWorkspaceDeclaration
+}
+
+// String names the Pipeline in conditions and logs.
+func (s pipelineSelection) String() string {
+    if s.Key != nil {
+        return s.Key.String()
+    }
+    return s.ID
+}
+
+// selectPipeline resolves spec.pipelineRef, falling back to the configured Pipeline.
+func selectPipeline(wl *workloadv1alpha1.Workload, cfg *config.Config) (pipelineSelection, error) {
+    ref := wl.Spec.PipelineRef
+    if ref == nil {
+        if r := cfg.PipelineResolver; r != nil {
+            return resolverSelection(r.Resolver, r.Params, r.Workspaces), nil
+        }
+        return localSelection(wl.Namespace, cfg.PipelineName), nil
+    }
+
+    set := 0
+    for _, ok := range []bool{ref.Name != "", ref.Catalog != "", ref.Resolver != nil} {
+        if ok {
+            set++
+        }
+    }
+    if set != 1 {
+        return pipelineSelection{}, fmt.Errorf("exactly one of spec.pipelineRef.name, catalog and resolver must be set")
+    }
+
+    switch {
+    case ref.Catalog != "":
+        if cfg.CatalogNamespace == "" {
+            return pipelineSelection{}, fmt.Errorf("spec.pipelineRef.catalog is set but no catalogNamespace is configured")
+        }
+        return pipelineSelection{
+            Key: &client.ObjectKey{Namespace: cfg.CatalogNamespace, Name: ref.Catalog},
+            Ref: pipeline.ClusterResolverRef(cfg.CatalogNamespace, ref.Catalog),
+            ID:  fmt.Sprintf("%s:%s/%s", pipeline.ClusterResolver, cfg.CatalogNamespace, ref.Catalog),
+        }, nil
+    case ref.Resolver != nil:
+        params := make(map[string]string, len(ref.Resolver.Params))
+        for _, p := range ref.Resolver.Params {
+            params[p.Name] = p.Value
+        }
+        return resolverSelection(ref.Resolver.Resolver, params, ref.Resolver.Workspaces), nil
+    default:
+        return localSelection(wl.Namespace, ref.Name), nil
+    }
+}
+
+func localSelection(namespace, name string) pipelineSelection {
+    return pipelineSelection{
+        Key: &client.ObjectKey{Namespace: namespace, Name: name},
+        Ref: &pipelinev1.PipelineRef{Name: name},
+        ID:  name,
+    }
+}
+
+func resolverSelection(resolver string, params map[string]string, workspaces []string) pipelineSelection {
+    ref := pipeline.ResolverPipelineRef(resolver, params)
+    return pipelineSelection{
+        Ref:        ref,
+        ID:         pipeline.ResolverID(ref),
+        Workspaces: pipeline.WorkspaceDeclarations(workspaces),
+    }
+}
+
+// resolvedPipelineSpec returns the Pipeline spec Tekton resolved into the status of the
+// last PipelineRun, if that run used ref. It returns nil while no such spec is known.
+func (r *WorkloadReconciler) resolvedPipelineSpec(ctx context.Context, wl *workloadv1alpha1.Workload, ref *pipelinev1.PipelineRef) (*pipelinev1.PipelineSpec, error) {
+    name := wl.Status.LastPipelineRunName
+    if name == "" {
+        return nil, nil
+    }
+    pr, err := r.Tekton.GetPipelineRun(ctx, client.ObjectKey{Namespace: wl.Namespace, Name: name})
+    if err != nil {
+        if apierrors.IsNotFound(err) {
+            return nil, nil
+        }
+        return nil, fmt.Errorf("failed to get PipelineRun %q: %w", name, err)
+    }
+    // 참조가 바뀌었다면 이전 실행의 spec 은 더 이상 유효하지 않습니다.
+    if !equality.Semantic.DeepEqual(pr.Spec.PipelineRef, ref) {
+        return nil, nil
+    }
+    return pr.Status.PipelineSpec, nil
+}
+
+// setIgnoredParams records the params the selected Pipeline does not declare in
+// status.ignoredParams, with an Event when the list changes.
+func (r *WorkloadReconciler) setIgnoredParams(wl *workloadv1alpha1.Workload, selected pipelineSelection, ignored []string) {
+    if slices.Equal(wl.Status.IgnoredParams, ignored) {
+        return
+    }
+    wl.Status.IgnoredParams = ignored
+    if len(ignored) > 0 {
+        r.Recorder.Eventf(wl, corev1.EventTypeWarning, reasonParamsIgnored,
+            "Pipeline %s does not declare params %s; they are not passed", selected, strings.Join(ignored, ", "))
+    }
+}
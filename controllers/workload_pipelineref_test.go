@@ -0,0 +1,226 @@
+// File: controllers/workload_pipelineref_test.go
+package controllers
+
+import (
+    "context"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+    metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+    "k8s.io/apimachinery/pkg/types"
+    ctrl "sigs.k8s.io/controller-runtime"
+
+    workloadv1alpha1 "tekton-controller/api/v1alpha1"
+    "tekton-controller/pkg/config"
+    "tekton-controller/pkg/git"
+    "tekton-controller/pkg/pipeline"
+)
+
+func TestSelectPipeline(t *testing.T) {
+    cfg := config.Default()
+    wl := &workloadv1alpha1.Workload{}
+    wl.SetNamespace("team-a")
+
+    // 지정하지 않으면 설정된 Pipeline
+    sel, err := selectPipeline(wl, cfg)
+    assert.NoError(t, err)
+    assert.Equal(t, "team-a/master-ci-pipeline", sel.Key.String())
+    assert.Equal(t, "master-ci-pipeline", sel.Ref.Name)
+    assert.Equal(t, "master-ci-pipeline", sel.ID)
+
+    wl.Spec.PipelineRef = &workloadv1alpha1.PipelineRef{Name: "maven-pipeline"}
+    sel, err = selectPipeline(wl, cfg)
+    assert.NoError(t, err)
+    assert.Equal(t, "team-a/maven-pipeline", sel.Key.String())
+
+    // catalog 는 catalogNamespace 설정이 필요
+    wl.Spec.PipelineRef = &workloadv1alpha1.PipelineRef{Catalog: "buildpacks"}
+    _, err = selectPipeline(wl, cfg)
+    assert.Error(t, err)
+
+    cfg.CatalogNamespace = "tekton-catalog"
+    sel, err = selectPipeline(wl, cfg)
+    assert.NoError(t, err)
+    assert.Equal(t, "tekton-catalog/buildpacks", sel.Key.String())
+    assert.EqualValues(t, pipeline.ClusterResolver, sel.Ref.Resolver)
+    assert.Equal(t, "", sel.Ref.Name)
+
+    wl.Spec.PipelineRef.Name = "maven-pipeline"
+    _, err = selectPipeline(wl, cfg)
+    assert.Error(t, err)
+}
+
+func TestSelectPipeline_Resolver(t *testing.T) {
+    cfg := config.Default()
+    cfg.PipelineResolver = &config.PipelineResolver{
+        Resolver:   pipeline.BundlesResolver,
+        Params:     map[string]string{"bundle": "registry.io/ci/pipelines:v1", "name": "master-ci-pipeline", "kind": "pipeline"},
+        Workspaces: []string{"shared-data"},
+    }
+    wl := &workloadv1alpha1.Workload{}
+    wl.SetNamespace("team-a")
+
+    // 설정의 리졸버가 pipelineName 보다 우선하며, 로컬 Pipeline 을 읽지 않습니다.
+    sel, err := selectPipeline(wl, cfg)
+    assert.NoError(t, err)
+    assert.Nil(t, sel.Key)
+    assert.EqualValues(t, pipeline.BundlesResolver, sel.Ref.Resolver)
+    assert.Equal(t, "bundles:bundle=registry.io/ci/pipelines:v1,kind=pipeline,name=master-ci-pipeline", sel.ID)
+    assert.Equal(t, sel.ID, sel.String())
+    assert.Len(t, sel.Workspaces, 1)
+
+    wl.Spec.PipelineRef = &workloadv1alpha1.PipelineRef{Resolver: &workloadv1alpha1.ResolverRef{
+        Resolver: pipeline.GitResolver,
+        Params: []workloadv1alpha1.ResolverParam{
+            {Name: "url", Value: "https://git.example.com/ci/pipelines.git"},
+            {Name: "pathInRepo", Value: "pipelines/maven.yaml"},
+            {Name: "revision", Value: "main"},
+        },
+    }}
+    sel, err = selectPipeline(wl, cfg)
+    assert.NoError(t, err)
+    assert.Nil(t, sel.Key)
+    assert.EqualValues(t, pipeline.GitResolver, sel.Ref.Resolver)
+    assert.Equal(t, "pathInRepo", sel.Ref.Params[0].Name)
+    assert.Empty(t, sel.Workspaces)
+}
+
+func TestResolvedPipelineSpec(t *testing.T) {
+    ref := pipeline.ResolverPipelineRef(pipeline.GitResolver, map[string]string{"url": "https://git.example.com/ci.git"})
+    pr := &pipelinev1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Namespace: "test-ns", Name: "app-run"}}
+    pr.Spec.PipelineRef = ref
+    pr.Status.PipelineSpec = &pipelinev1.PipelineSpec{Params: pipelinev1.ParamSpecs{{Name: "ci-git-url"}}}
+
+    r, _ := newTestReconciler(t, pr)
+    wl := &workloadv1alpha1.Workload{ObjectMeta: metav1.ObjectMeta{Namespace: "test-ns", Name: "app"}}
+    ctx := context.Background()
+
+    // 아직 실행된 PipelineRun 이 없음
+    spec, err := r.resolvedPipelineSpec(ctx, wl, ref)
+    assert.NoError(t, err)
+    assert.Nil(t, spec)
+
+    wl.Status.LastPipelineRunName = "app-run"
+    spec, err = r.resolvedPipelineSpec(ctx, wl, ref)
+    assert.NoError(t, err)
+    assert.NotNil(t, spec)
+    assert.Equal(t, "ci-git-url", spec.Params[0].Name)
+
+    // 다른 참조로 만든 실행의 spec 은 사용하지 않습니다.
+    other := pipeline.ResolverPipelineRef(pipeline.GitResolver, map[string]string{"url": "https://git.example.com/other.git"})
+    spec, err = r.resolvedPipelineSpec(ctx, wl, other)
+    assert.NoError(t, err)
+    assert.Nil(t, spec)
+}
+
+func TestSetIgnoredParams(t *testing.T) {
+    r, recorder := newTestReconciler(t)
+    wl := &workloadv1alpha1.Workload{ObjectMeta: metav1.ObjectMeta{Namespace: "test-ns", Name: "app"}}
+    sel := localSelection("test-ns", "master-ci-pipeline")
+
+    r.setIgnoredParams(wl, sel, []string{"unknown"})
+    assert.Equal(t, []string{"unknown"}, wl.Status.IgnoredParams)
+    assert.Len(t, recorder.Events, 1)
+
+    // 같은 목록이면 이벤트를 다시 남기지 않습니다.
+    r.setIgnoredParams(wl, sel, []string{"unknown"})
+    assert.Len(t, recorder.Events, 1)
+
+    r.setIgnoredParams(wl, sel, nil)
+    assert.Nil(t, wl.Status.IgnoredParams)
+    assert.Len(t, recorder.Events, 1)
+}
+
+func TestReconcile_IgnoresUndeclaredParams(t *testing.T) {
+    // workloadname 을 선언하지 않은 이전 Pipeline
+    pl := &pipelinev1.Pipeline{ObjectMeta: metav1.ObjectMeta{Namespace: "test-ns", Name: "master-ci-pipeline"}}
+    pl.Spec.Params = pipelinev1.ParamSpecs{
+        {Name: "ci-git-url", Type: pipelinev1.ParamTypeString},
+        {Name: "ci-git-revision", Type: pipelinev1.ParamTypeString},
+        {Name: "image_repo_address", Type: pipelinev1.ParamTypeString},
+    }
+    sha := "0123456789abcdef0123456789abcdef01234567"
+    wl := &workloadv1alpha1.Workload{ObjectMeta: metav1.ObjectMeta{
+        Namespace: "test-ns", Name: "app", Finalizers: []string{finalizerName},
+    }}
+    wl.Spec.Source = &workloadv1alpha1.Source{Git: &workloadv1alpha1.GitSource{
+        URL: "https://gitlab.com/team/app.git",
+        Ref: workloadv1alpha1.GitRef{Commit: sha},
+    }}
+
+    r, _ := newTestReconciler(t, pl, wl)
+    r.Config = config.NewStore(config.Default())
+    r.GitResolver = &git.Resolver{}
+    ctx := context.Background()
+    key := types.NamespacedName{Namespace: "test-ns", Name: "app"}
+
+    _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+    assert.NoError(t, err)
+
+    got := &workloadv1alpha1.Workload{}
+    assert.NoError(t, r.Get(ctx, key, got))
+    assert.Contains(t, got.Status.IgnoredParams, pipeline.WorkloadNameParam)
+    assert.NotEmpty(t, got.Status.LastPipelineRunName)
+
+    runs, err := r.Tekton.ListPipelineRuns(ctx)
+    assert.NoError(t, err)
+    if assert.Len(t, runs, 1) {
+        var names []string
+        for _, p := range runs[0].Spec.Params {
+            names = append(names, p.Name)
+        }
+        assert.Equal(t, []string{"ci-git-revision", "ci-git-url", "image_repo_address"}, names)
+    }
+}
+
+func TestReconcile_ResolverPipelineKeepsBuildHash(t *testing.T) {
+    sha := "0123456789abcdef0123456789abcdef01234567"
+    wl := &workloadv1alpha1.Workload{ObjectMeta: metav1.ObjectMeta{
+        Namespace: "test-ns", Name: "app", Finalizers: []string{finalizerName},
+    }}
+    wl.Spec.Source = &workloadv1alpha1.Source{Git: &workloadv1alpha1.GitSource{
+        URL: "https://gitlab.com/team/app.git",
+        Ref: workloadv1alpha1.GitRef{Commit: sha},
+    }}
+    wl.Spec.PipelineRef = &workloadv1alpha1.PipelineRef{Resolver: &workloadv1alpha1.ResolverRef{
+        Resolver: pipeline.GitResolver,
+        Params:   []workloadv1alpha1.ResolverParam{{Name: "url", Value: "https://git.example.com/ci/pipelines.git"}},
+    }}
+
+    r, _ := newTestReconciler(t, wl)
+    r.Config = config.NewStore(config.Default())
+    r.GitResolver = &git.Resolver{}
+    ctx := context.Background()
+    key := types.NamespacedName{Namespace: "test-ns", Name: "app"}
+
+    // 첫 실행에서는 Pipeline 스펙을 알 수 없습니다.
+    _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+    assert.NoError(t, err)
+    runs, err := r.Tekton.ListPipelineRuns(ctx)
+    assert.NoError(t, err)
+    if !assert.Len(t, runs, 1) {
+        return
+    }
+
+    // Tekton 이 참조를 풀어 status.pipelineSpec 을 채운 뒤 다시 reconcile 합니다.
+    pr := &pipelinev1.PipelineRun{}
+    assert.NoError(t, r.Get(ctx, types.NamespacedName{Namespace: "test-ns", Name: runs[0].Name}, pr))
+    pr.Status.PipelineSpec = &pipelinev1.PipelineSpec{Params: pipelinev1.ParamSpecs{
+        {Name: "ci-git-url", Type: pipelinev1.ParamTypeString},
+        {Name: "ci-git-revision", Type: pipelinev1.ParamTypeString},
+        {Name: "image_repo_address", Type: pipelinev1.ParamTypeString},
+    }}
+    assert.NoError(t, r.Update(ctx, pr))
+
+    _, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+    assert.NoError(t, err)
+
+    got := &workloadv1alpha1.Workload{}
+    assert.NoError(t, r.Get(ctx, key, got))
+    assert.Contains(t, got.Status.IgnoredParams, pipeline.WorkloadNameParam)
+    assert.Equal(t, runs[0].Name, got.Status.LastPipelineRunName)
+    runs, err = r.Tekton.ListPipelineRuns(ctx)
+    assert.NoError(t, err)
+    assert.Len(t, runs, 1)
+}
@@ -0,0 +1,111 @@
+// File: controllers/workload_pipelinerun.go
+package controllers
+
+import (
+    "context"
+    "fmt"
+
+    pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+    corev1 "k8s.io/api/core/v1"
+    apierrors "k8s.io/apimachinery/pkg/api/errors"
+    metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+    "knative.dev/pkg/apis"
+    "sigs.k8s.io/controller-runtime/pkg/client"
+
+    workloadv1alpha1 "tekton-controller/api/v1alpha1"
+    "tekton-controller/pkg/pipeline"
+)
+
+const taskRunKind = "TaskRun"
+
+// syncPipelineRunStatus mirrors the PipelineRun named by status.lastPipelineRunName into
+// status.lastPipelineRun and the BuildSucceeded condition, and emits an Event when it finishes.
+func (r *WorkloadReconciler) syncPipelineRunStatus(ctx context.Context, wl *workloadv1alpha1.Workload) error {
+    name := wl.Status.LastPipelineRunName
+    if name == "" {
+        return nil
+    }
+
+    pr, err := r.Tekton.GetPipelineRun(ctx, client.ObjectKey{Namespace: wl.Namespace, Name: name})
+    if err != nil {
+        if apierrors.IsNotFound(err) {
+            wl.Status.LastPipelineRun = nil
+            setCondition(wl, workloadv1alpha1.ConditionBuildSucceeded, metav1.ConditionUnknown, reasonPipelineRunNotFound,
+                fmt.Sprintf("PipelineRun %q not found", name))
+            return nil
+        }
+        return fmt.Errorf("failed to get PipelineRun %q: %w", name, err)
+    }
+
+    summary, err := summarizePipelineRun(ctx, r.Tekton, pr)
+    if err != nil {
+        return err
+    }
+    prev := wl.Status.LastPipelineRun
+    wl.Status.LastPipelineRun = summary
+
+    reason := summary.Reason
+    if reason == "" {
+        reason = reasonPipelineRunPending
+    }
+    message := summary.Message
+    if summary.FailedTaskRun != "" {
+        message = fmt.Sprintf("TaskRun %q failed: %s", summary.FailedTaskRun, summary.Message)
+    }
+    setCondition(wl, workloadv1alpha1.ConditionBuildSucceeded, summary.Succeeded, reason, message)
+
+    // 완료 상태로 바뀐 경우에만 이벤트를 남깁니다.
+    if prev != nil && prev.Name == summary.Name && prev.Succeeded == summary.Succeeded {
+        return nil
+    }
+    switch summary.Succeeded {
+    case metav1.ConditionTrue:
+        r.Recorder.Eventf(wl, corev1.EventTypeNormal, reasonBuildSucceeded,
+            "PipelineRun %s succeeded for %s", name, wl.Status.LastAppliedRevision)
+    case metav1.ConditionFalse:
+        r.Recorder.Eventf(wl, corev1.EventTypeWarning, reasonBuildFailed, "PipelineRun %s failed: %s", name, message)
+    }
+    return nil
+}
+
+// summarizePipelineRun converts the Succeeded condition and timings of pr into a
+// PipelineRunStatus. For failed runs the first failed child TaskRun is looked up.
+func summarizePipelineRun(ctx context.Context, c *pipeline.Client, pr *pipelinev1.PipelineRun) (*workloadv1alpha1.PipelineRunStatus, error) {
+    summary := &workloadv1alpha1.PipelineRunStatus{
+        Name:           pr.Name,
+        Succeeded:      metav1.ConditionUnknown,
+        StartTime:      pr.Status.StartTime,
+        CompletionTime: pr.Status.CompletionTime,
+    }
+    cond := pr.Status.GetCondition(apis.ConditionSucceeded)
+    if cond == nil {
+        return summary, nil
+    }
+    summary.Succeeded = metav1.ConditionStatus(cond.Status)
+    summary.Reason = cond.Reason
+    summary.Message = cond.Message
+    if summary.Succeeded != metav1.ConditionFalse {
+        return summary, nil
+    }
+
+    for _, child := range pr.Status.ChildReferences {
+        if child.Kind != taskRunKind {
+            continue
+        }
+        tr, err := c.GetTaskRun(ctx, client.ObjectKey{Namespace: pr.Namespace, Name: child.Name})
+        if err != nil {
+            if apierrors.IsNotFound(err) {
+                continue
+            }
+            return nil, fmt.Errorf("failed to get TaskRun %q: %w", child.Name, err)
+        }
+        if trCond := tr.Status.GetCondition(apis.ConditionSucceeded); trCond != nil && trCond.IsFalse() {
+            summary.FailedTaskRun = tr.Name
+            if trCond.Message != "" {
+                summary.Message = trCond.Message
+            }
+            break
+        }
+    }
+    return summary, nil
+}
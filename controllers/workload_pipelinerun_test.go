@@ -0,0 +1,99 @@
+// File: controllers/workload_pipelinerun_test.go
+package controllers
+
+import (
+    "context"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+    corev1 "k8s.io/api/core/v1"
+    "k8s.io/apimachinery/pkg/api/meta"
+    metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+    "k8s.io/apimachinery/pkg/runtime"
+    "k8s.io/client-go/tools/record"
+    "knative.dev/pkg/apis"
+    "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+    workloadv1alpha1 "tekton-controller/api/v1alpha1"
+    "tekton-controller/pkg/pipeline"
+)
+
+func newTestReconciler(t *testing.T, objs ...runtime.Object) (*WorkloadReconciler, *record.FakeRecorder) {
+    scheme := setupScheme()
+    assert.NoError(t, pipelinev1.AddToScheme(scheme))
+    assert.NoError(t, corev1.AddToScheme(scheme))
+    recorder := record.NewFakeRecorder(10)
+    cli := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objs...).
+        WithStatusSubresource(&workloadv1alpha1.Workload{}).Build()
+    tekton, err := pipeline.NewClient(cli, pipeline.APIVersionV1)
+    assert.NoError(t, err)
+    return &WorkloadReconciler{Client: cli, Scheme: scheme, Recorder: recorder, Tekton: tekton}, recorder
+}
+
+func TestSyncPipelineRunStatus_Failed(t *testing.T) {
+    start := metav1.Unix(1700000000, 0)
+    pr := &pipelinev1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Namespace: "test-ns", Name: "app-run"}}
+    pr.Status.StartTime = &start
+    pr.Status.CompletionTime = &start
+    pr.Status.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionFalse,
+        Reason: "Failed", Message: "Tasks Completed: 2 (Failed: 1, Cancelled 0), Skipped: 0"})
+    pr.Status.ChildReferences = []pipelinev1.ChildStatusReference{
+        {TypeMeta: runtime.TypeMeta{Kind: taskRunKind}, Name: "app-run-clone"},
+        {TypeMeta: runtime.TypeMeta{Kind: taskRunKind}, Name: "app-run-build"},
+    }
+    clone := &pipelinev1.TaskRun{ObjectMeta: metav1.ObjectMeta{Namespace: "test-ns", Name: "app-run-clone"}}
+    clone.Status.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue, Reason: "Succeeded"})
+    build := &pipelinev1.TaskRun{ObjectMeta: metav1.ObjectMeta{Namespace: "test-ns", Name: "app-run-build"}}
+    build.Status.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionFalse,
+        Reason: "Failed", Message: `"step-build" exited with code 1`})
+
+    r, recorder := newTestReconciler(t, pr, clone, build)
+    wl := &workloadv1alpha1.Workload{ObjectMeta: metav1.ObjectMeta{Namespace: "test-ns", Name: "app"}}
+    wl.Status.LastPipelineRunName = "app-run"
+
+    assert.NoError(t, r.syncPipelineRunStatus(context.Background(), wl))
+    last := wl.Status.LastPipelineRun
+    assert.NotNil(t, last)
+    assert.Equal(t, metav1.ConditionFalse, last.Succeeded)
+    assert.Equal(t, "app-run-build", last.FailedTaskRun)
+    assert.Equal(t, `"step-build" exited with code 1`, last.Message)
+    assert.True(t, start.Equal(last.StartTime))
+    cond := meta.FindStatusCondition(wl.Status.Conditions, workloadv1alpha1.ConditionBuildSucceeded)
+    assert.NotNil(t, cond)
+    assert.Equal(t, metav1.ConditionFalse, cond.Status)
+    assert.Equal(t, "Failed", cond.Reason)
+    assert.Len(t, recorder.Events, 1)
+    assert.Contains(t, <-recorder.Events, "Warning "+reasonBuildFailed)
+
+    // 상태가 그대로면 이벤트를 다시 남기지 않음
+    assert.NoError(t, r.syncPipelineRunStatus(context.Background(), wl))
+    assert.Len(t, recorder.Events, 0)
+}
+
+func TestSyncPipelineRunStatus_RunningThenSucceeded(t *testing.T) {
+    pr := &pipelinev1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Namespace: "test-ns", Name: "app-run"}}
+    pr.Status.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionUnknown, Reason: "Running"})
+    r, recorder := newTestReconciler(t, pr)
+    wl := &workloadv1alpha1.Workload{ObjectMeta: metav1.ObjectMeta{Namespace: "test-ns", Name: "app"}}
+    wl.Status.LastPipelineRunName = "app-run"
+    ctx := context.Background()
+
+    assert.NoError(t, r.syncPipelineRunStatus(ctx, wl))
+    assert.Equal(t, metav1.ConditionUnknown, wl.Status.LastPipelineRun.Succeeded)
+    assert.Len(t, recorder.Events, 0)
+
+    pr.Status.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue, Reason: "Succeeded"})
+    assert.NoError(t, r.Update(ctx, pr))
+    assert.NoError(t, r.syncPipelineRunStatus(ctx, wl))
+    assert.True(t, meta.IsStatusConditionTrue(wl.Status.Conditions, workloadv1alpha1.ConditionBuildSucceeded))
+    assert.Len(t, recorder.Events, 1)
+    assert.Contains(t, <-recorder.Events, "Normal "+reasonBuildSucceeded)
+
+    // PipelineRun 이 삭제되면 요약을 지우고 Unknown 으로 표시
+    assert.NoError(t, r.Delete(ctx, pr))
+    assert.NoError(t, r.syncPipelineRunStatus(ctx, wl))
+    assert.Nil(t, wl.Status.LastPipelineRun)
+    cond := meta.FindStatusCondition(wl.Status.Conditions, workloadv1alpha1.ConditionBuildSucceeded)
+    assert.Equal(t, reasonPipelineRunNotFound, cond.Reason)
+}
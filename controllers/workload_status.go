@@ -0,0 +1,74 @@
+// File: controllers/workload_status.go
+package controllers
+
+import (
+    "context"
+
+    "k8s.io/apimachinery/pkg/api/equality"
+    "k8s.io/apimachinery/pkg/api/meta"
+    metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+    "sigs.k8s.io/controller-runtime/pkg/client"
+
+    workloadv1alpha1 "tekton-controller/api/v1alpha1"
+)
+
+// --- Condition Reason Constants ---
+const (
+    reasonInvalidSource           = "InvalidSource"
+    reasonSecretParseFailed       = "SecretParseFailed"
+    reasonGitResolveFailed        = "GitResolveFailed"
+    reasonResolved                = "Resolved"
+    reasonPipelineTemplateMissing = "PipelineTemplateMissing"
+    reasonInvalidPipelineRef      = "InvalidPipelineRef"
+    reasonPipelineIncompatible    = "PipelineIncompatible"
+    reasonInvalidResources        = "InvalidResources"
+    reasonMissingParams           = "MissingParams"
+    reasonParamsIgnored           = "ParamsIgnored"
+    reasonPipelineRunFailed       = "PipelineRunCreateFailed"
+    reasonPipelineRunCreated      = "PipelineRunCreated"
+    reasonPipelineRunUpToDate     = "PipelineRunUpToDate"
+    reasonPipelineRunNotFound     = "PipelineRunNotFound"
+    reasonPipelineRunQueued       = "PipelineRunQueued"
+    reasonPipelineRunCancelled    = "PipelineRunCancelled"
+    reasonPipelineRunPending      = "Pending"
+    reasonBuildSucceeded          = "BuildSucceeded"
+    reasonBuildFailed             = "BuildFailed"
+    reasonRoutingFailed           = "ListenerRoutingFailed"
+    reasonListenerRouted          = "ListenerRouted"
+    reasonReconciled              = "Reconciled"
+    reasonCleanupFailed           = "CleanupFailed"
+)
+
+// setCondition sets a condition on the Workload, stamping the current generation.
+func setCondition(wl *workloadv1alpha1.Workload, condType string, status metav1.ConditionStatus, reason, message string) {
+    meta.SetStatusCondition(&wl.Status.Conditions, metav1.Condition{
+        Type:               condType,
+        Status:             status,
+        Reason:             reason,
+        Message:            message,
+        ObservedGeneration: wl.Generation,
+    })
+}
+
+// markFailed sets condType and Ready to False with the same reason and moves the Workload to the Failed phase.
+func markFailed(wl *workloadv1alpha1.Workload, condType, reason, message string) {
+    setCondition(wl, condType, metav1.ConditionFalse, reason, message)
+    setCondition(wl, workloadv1alpha1.ConditionReady, metav1.ConditionFalse, reason, message)
+    wl.Status.Phase = workloadv1alpha1.PhaseFailed
+    wl.Status.ObservedGeneration = wl.Generation
+}
+
+// markReady sets Ready to True and moves the Workload to the Ready phase.
+func markReady(wl *workloadv1alpha1.Workload) {
+    setCondition(wl, workloadv1alpha1.ConditionReady, metav1.ConditionTrue, reasonReconciled, "Workload reconciled successfully")
+    wl.Status.Phase = workloadv1alpha1.PhaseReady
+    wl.Status.ObservedGeneration = wl.Generation
+}
+
+// patchStatus patches the status subresource if it differs from base.
+func patchStatus(ctx context.Context, c client.Client, wl, base *workloadv1alpha1.Workload) error {
+    if equality.Semantic.DeepEqual(base.Status, wl.Status) {
+        return nil
+    }
+    return c.Status().Patch(ctx, wl, client.MergeFrom(base))
+}
@@ -0,0 +1,60 @@
+// File: controllers/workload_status_test.go
+package controllers
+
+import (
+    "context"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "k8s.io/apimachinery/pkg/api/meta"
+    metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+    "sigs.k8s.io/controller-runtime/pkg/client"
+    "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+    workloadv1alpha1 "tekton-controller/api/v1alpha1"
+)
+
+func TestMarkFailedThenReady(t *testing.T) {
+    wl := &workloadv1alpha1.Workload{}
+    wl.Generation = 3
+
+    markFailed(wl, workloadv1alpha1.ConditionSourceResolved, reasonGitResolveFailed, "boom")
+    assert.Equal(t, workloadv1alpha1.PhaseFailed, wl.Status.Phase)
+    ready := meta.FindStatusCondition(wl.Status.Conditions, workloadv1alpha1.ConditionReady)
+    assert.NotNil(t, ready)
+    assert.Equal(t, metav1.ConditionFalse, ready.Status)
+    assert.Equal(t, reasonGitResolveFailed, ready.Reason)
+    assert.Equal(t, int64(3), ready.ObservedGeneration)
+
+    setCondition(wl, workloadv1alpha1.ConditionSourceResolved, metav1.ConditionTrue, reasonResolved, "ok")
+    markReady(wl)
+    assert.Equal(t, workloadv1alpha1.PhaseReady, wl.Status.Phase)
+    assert.True(t, meta.IsStatusConditionTrue(wl.Status.Conditions, workloadv1alpha1.ConditionSourceResolved))
+    assert.True(t, meta.IsStatusConditionTrue(wl.Status.Conditions, workloadv1alpha1.ConditionReady))
+    assert.Equal(t, int64(3), wl.Status.ObservedGeneration)
+}
+
+func TestPatchStatus_WritesStatusSubresource(t *testing.T) {
+    scheme := setupScheme()
+    wl := &workloadv1alpha1.Workload{}
+    wl.SetNamespace("test-ns")
+    wl.SetName("test-wl")
+    cli := fake.NewClientBuilder().
+        WithScheme(scheme).
+        WithObjects(wl).
+        WithStatusSubresource(wl).
+        Build()
+    ctx := context.Background()
+
+    current := &workloadv1alpha1.Workload{}
+    assert.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(wl), current))
+    base := current.DeepCopy()
+    current.Status.LastCommitSHA = "0123456789abcdef"
+    markReady(current)
+    assert.NoError(t, patchStatus(ctx, cli, current, base))
+
+    updated := &workloadv1alpha1.Workload{}
+    assert.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(wl), updated))
+    assert.Equal(t, "0123456789abcdef", updated.Status.LastCommitSHA)
+    assert.Equal(t, workloadv1alpha1.PhaseReady, updated.Status.Phase)
+}
@@ -4,18 +4,36 @@ package main
 import (
     "flag"
     "os"
+    "time"
 
     corev1 "k8s.io/api/core/v1"
     "k8s.io/apimachinery/pkg/runtime"
+    "k8s.io/apimachinery/pkg/runtime/schema"
     utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+    "k8s.io/client-go/discovery"
     clientgoscheme "k8s.io/client-go/kubernetes/scheme"
     ctrl "sigs.k8s.io/controller-runtime"
+    "sigs.k8s.io/controller-runtime/pkg/event"
+    "sigs.k8s.io/controller-runtime/pkg/healthz"
     "sigs.k8s.io/controller-runtime/pkg/log/zap"
+    metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+    "sigs.k8s.io/controller-runtime/pkg/webhook"
 
+    pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
     pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+    workloadv1alpha1 "tekton-controller/api/v1alpha1"
     "tekton-controller/controllers"
+    "tekton-controller/pkg/config"
+    "tekton-controller/pkg/git"
+    "tekton-controller/pkg/gitwebhook"
+    "tekton-controller/pkg/health"
+    "tekton-controller/pkg/pipeline"
+    "tekton-controller/pkg/workloadwebhook"
 )
 
+// gitWebhookSecretEnv holds the shared secret for GitLab/GitHub/Gitea push webhooks.
+const gitWebhookSecretEnv = "GIT_WEBHOOK_SECRET"
+
 var (
     scheme   = runtime.NewScheme()
     setupLog = ctrl.Log.WithName("setup")
@@ -26,18 +44,45 @@ func init() {
     utilruntime.Must(clientgoscheme.AddToScheme(scheme))
     // core/v1 Namespace 타입 스킴에 추가
     utilruntime.Must(corev1.AddToScheme(scheme))
-    // unstructured 로 Workload, HTTPProxy를 감시하기 때문에 별도 CRD 스킴 추가 불필요
+    // Workload CRD 타입 등록 (HTTPProxy는 unstructured로 다루므로 불필요)
+    utilruntime.Must(workloadv1alpha1.AddToScheme(scheme))
 
-    // Tekton 스킴 등록을 init() 함수로 이동
+    // Tekton 스킴 등록 (v1beta1 은 --tekton-api-version=v1beta1 일 때만 사용)
+    utilruntime.Must(pipelinev1.AddToScheme(scheme))
     utilruntime.Must(pipelinev1beta1.AddToScheme(scheme))
 }
 
 func main() {
     var metricsAddr string
     var enableLeaderElection bool
+    var gitPollInterval time.Duration
+    var gitWebhookAddr string
+    var enableAdmissionWebhooks bool
+    var webhookPort int
+    var webhookCertDir string
+    var configFile string
+    var probeAddr string
+    var readinessGitURL string
+    var tektonAPIVersion string
 
     flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
     flag.BoolVar(&enableLeaderElection, "leader-elect", false, "Enable leader election for controller manager.")
+    flag.DurationVar(&gitPollInterval, "git-poll-interval", 5*time.Minute,
+        "Default interval for re-resolving each Workload's Git ref to detect new commits. 0 disables polling.")
+    flag.StringVar(&gitWebhookAddr, "git-webhook-bind-address", ":8082",
+        "The address the Git push webhook receiver binds to. Empty disables the receiver.")
+    flag.BoolVar(&enableAdmissionWebhooks, "enable-admission-webhooks", false,
+        "Serve the Workload admission webhooks. Requires a serving certificate in --webhook-cert-dir.")
+    flag.IntVar(&webhookPort, "webhook-port", 9443, "The port the admission webhook server binds to.")
+    flag.StringVar(&webhookCertDir, "webhook-cert-dir", "",
+        "Directory holding tls.crt and tls.key for the admission webhook server. Defaults to the controller-runtime location.")
+    flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+    flag.StringVar(&readinessGitURL, "readiness-git-url", "",
+        "If set, readiness also requires anonymous ls-remote access to this Git repository URL.")
+    flag.StringVar(&tektonAPIVersion, "tekton-api-version", pipeline.APIVersionV1,
+        "tekton.dev API version used for Pipelines and PipelineRuns: v1, or v1beta1 for Tekton Pipelines releases that do not serve v1.")
+    flag.StringVar(&configFile, "config", "",
+        "Path to the controller configuration file (YAML), reloaded on change. Built-in defaults are used when empty.")
     flag.Parse()
 
     ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
@@ -45,8 +90,14 @@ func main() {
 
     mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
         Scheme:                 scheme,
+        Metrics:                metricsserver.Options{BindAddress: metricsAddr},
         LeaderElection:         enableLeaderElection,
         LeaderElectionID:       "tekton-controller-lock",
+        HealthProbeBindAddress: probeAddr,
+        WebhookServer:          webhook.NewServer(webhook.Options{
+            Port:    webhookPort,
+            CertDir: webhookCertDir,
+        }),
     })
 
     if err != nil {
@@ -54,27 +105,118 @@ func main() {
         os.Exit(1)
     }
 
-    // 기존 WorkloadReconciler (unstructured)
+    cfg, err := config.Load(configFile)
+    if err != nil {
+        setupLog.Error(err, "unable to load controller configuration")
+        os.Exit(1)
+    }
+    configStore := config.NewStore(cfg)
+    if configFile != "" {
+        if err = mgr.Add(&config.Watcher{Path: configFile, Store: configStore}); err != nil {
+            setupLog.Error(err, "unable to add configuration watcher")
+            os.Exit(1)
+        }
+    }
+
+    tekton, err := pipeline.NewClient(mgr.GetClient(), tektonAPIVersion)
+    if err != nil {
+        setupLog.Error(err, "invalid --tekton-api-version")
+        os.Exit(1)
+    }
+
+    gitResolver := git.NewResolver()
+
+    // Git push 웹훅 수신기 (GitLab / GitHub / Gitea)
+    var gitEvents chan event.GenericEvent
+    if secret := os.Getenv(gitWebhookSecretEnv); gitWebhookAddr != "" && secret != "" {
+        gitEvents = make(chan event.GenericEvent, 100)
+        if err = mgr.Add(&gitwebhook.Receiver{
+            Client:      mgr.GetClient(),
+            Resolver:    gitResolver,
+            Secret:      []byte(secret),
+            Events:      gitEvents,
+            BindAddress: gitWebhookAddr,
+        }); err != nil {
+            setupLog.Error(err, "unable to add Git webhook receiver")
+            os.Exit(1)
+        }
+    } else if gitWebhookAddr != "" {
+        setupLog.Info("Git webhook receiver disabled, secret not set", "env", gitWebhookSecretEnv)
+    }
+
+    // WorkloadReconciler (typed api/v1alpha1)
     if err = (&controllers.WorkloadReconciler{
-        Client: mgr.GetClient(),
-        Scheme: mgr.GetScheme(),
+        Client:       mgr.GetClient(),
+        Scheme:       mgr.GetScheme(),
+        GitResolver:  gitResolver,
+        Recorder:     mgr.GetEventRecorderFor("workload-controller"),
+        Tekton:       tekton,
+        Config:       configStore,
+        PollInterval: gitPollInterval,
+        GitEvents:    gitEvents,
     }).SetupWithManager(mgr); err != nil {
         setupLog.Error(err, "unable to create controller", "controller", "Workload")
         os.Exit(1)
     }
 
+    // Workload admission webhooks (defaulting + validation)
+    if enableAdmissionWebhooks {
+        if err = workloadwebhook.SetupWithManager(mgr, configStore); err != nil {
+            setupLog.Error(err, "unable to create webhook", "webhook", "Workload")
+            os.Exit(1)
+        }
+    }
+
     // 네임스페이스 삭제 정리용 Reconciler
     if err = (&controllers.NamespaceCleanupReconciler{
-        Client: mgr.GetClient(),
-        Scheme: mgr.GetScheme(),
+        Client:   mgr.GetClient(),
+        Scheme:   mgr.GetScheme(),
+        Config:   configStore,
+        Recorder: mgr.GetEventRecorderFor("namespace-cleanup-controller"),
+        Tekton:   tekton,
     }).SetupWithManager(mgr); err != nil {
         setupLog.Error(err, "unable to create controller", "controller", "NamespaceCleanup")
         os.Exit(1)
     }
 
+    if err := addHealthChecks(mgr, tekton.GroupVersion(), enableAdmissionWebhooks, readinessGitURL); err != nil {
+        setupLog.Error(err, "unable to set up health checks")
+        os.Exit(1)
+    }
+
     setupLog.Info("starting manager")
     if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
         setupLog.Error(err, "problem running manager")
         os.Exit(1)
     }
 }
+
+// addHealthChecks는 /healthz 와 /readyz 체크를 등록합니다.
+// readiness 는 캐시 동기화, Workload 및 Tekton Pipeline CRD 노출, (선택) Git 접속을 요구합니다.
+func addHealthChecks(mgr ctrl.Manager, tektonGV schema.GroupVersion, webhooksEnabled bool, gitURL string) error {
+    if err := mgr.AddHealthzCheck("ping", healthz.Ping); err != nil {
+        return err
+    }
+
+    dc, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
+    if err != nil {
+        return err
+    }
+    checks := map[string]healthz.Checker{
+        "cache-sync":          health.CacheSynced(mgr.GetCache()),
+        "workload-crd":        health.ResourceDiscoverable(dc, workloadv1alpha1.GroupVersion, "workloads"),
+        "tekton-pipeline-crd": health.ResourceDiscoverable(dc, tektonGV, "pipelines"),
+    }
+    if webhooksEnabled {
+        checks["webhook"] = mgr.GetWebhookServer().StartedChecker()
+    }
+    if gitURL != "" {
+        checks["git"] = health.GitReachable(gitURL, nil, health.DefaultGitCheckInterval)
+    }
+    for name, check := range checks {
+        if err := mgr.AddReadyzCheck(name, check); err != nil {
+            return err
+        }
+    }
+    return nil
+}
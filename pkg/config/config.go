@@ -0,0 +1,225 @@
+// File: pkg/config/config.go
+package config
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+
+	"tekton-controller/pkg/pipeline"
+)
+
+// Defaults are applied to Workloads that do not set the corresponding value.
+type Defaults struct {
+	// ImageRepoAddress is the registry images are pushed to (image-repo-address param).
+	ImageRepoAddress string `json:"imageRepoAddress,omitempty"`
+	// ImageRepoPath is the project path inside the registry (image-repo-path param).
+	ImageRepoPath string `json:"imageRepoPath,omitempty"`
+	// GitSecretName is the Secret used for Git authentication (build-git-secret annotation).
+	GitSecretName string `json:"gitSecretName,omitempty"`
+	// WorkspaceClaimName is the PVC bound to the shared-data workspace (build-workspace-claim annotation).
+	WorkspaceClaimName string `json:"workspaceClaimName,omitempty"`
+}
+
+// Listener configures the per-namespace listener HTTPProxy and the global
+// HTTPProxy that includes it.
+type Listener struct {
+	// GlobalProxyNamespace and GlobalProxyName locate the HTTPProxy that includes every listener.
+	GlobalProxyNamespace string `json:"globalProxyNamespace,omitempty"`
+	GlobalProxyName      string `json:"globalProxyName,omitempty"`
+	// ServiceName is the EventListener Service routed to, unless the Workload's
+	// listenerService annotation overrides it.
+	ServiceName string `json:"serviceName,omitempty"`
+	// Port is the EventListener Service port.
+	Port int64 `json:"port,omitempty"`
+}
+
+// History holds the default number of completed PipelineRuns kept per Workload
+// when spec.build.history does not set a limit.
+type History struct {
+	// SuccessfulRunsLimit is the number of succeeded PipelineRuns to keep.
+	SuccessfulRunsLimit int32 `json:"successfulRunsLimit,omitempty"`
+	// FailedRunsLimit is the number of failed PipelineRuns to keep.
+	FailedRunsLimit int32 `json:"failedRunsLimit,omitempty"`
+}
+
+// Requeue holds the delays used when a reconcile cannot make progress.
+type Requeue struct {
+	// PermissionError is used when the controller is forbidden to read a Workload.
+	PermissionError metav1.Duration `json:"permissionError,omitempty"`
+	// GitError is used when the Git secret cannot be read or the ref cannot be resolved.
+	GitError metav1.Duration `json:"gitError,omitempty"`
+	// NotFound is used when the Pipeline template does not exist yet.
+	NotFound metav1.Duration `json:"notFound,omitempty"`
+}
+
+// Config is the controller configuration.
+type Config struct {
+	// PipelineName is the Pipeline in the Workload's namespace that PipelineRuns reference.
+	PipelineName string `json:"pipelineName,omitempty"`
+	// TektonEnabledLabel marks namespaces whose Tekton resources are cleaned up on deletion.
+	// The label value must be "true".
+	TektonEnabledLabel string `json:"tektonEnabledLabel,omitempty"`
+	// PipelineResolver, when set, replaces PipelineName for Workloads without
+	// spec.pipelineRef, so the Pipeline need not exist in every namespace.
+	PipelineResolver *PipelineResolver `json:"pipelineResolver,omitempty"`
+	// CatalogNamespace holds the shared Pipelines Workloads select with
+	// spec.pipelineRef.catalog. Empty disables catalog references.
+	CatalogNamespace string `json:"catalogNamespace,omitempty"`
+	// ParamAliases maps a param name to the name a Pipeline may declare instead,
+	// e.g. image-repo-address to image_repo_address. Aliases apply both ways and
+	// configured entries are added to the defaults.
+	ParamAliases map[string]string `json:"paramAliases,omitempty"`
+	// PipelineRunTimeout is set as spec.timeouts.pipeline on new PipelineRuns.
+	// Zero keeps Tekton's default timeout.
+	PipelineRunTimeout metav1.Duration `json:"pipelineRunTimeout,omitempty"`
+
+	Defaults Defaults `json:"defaults,omitempty"`
+	Listener Listener `json:"listener,omitempty"`
+	History  History  `json:"history,omitempty"`
+	Requeue  Requeue  `json:"requeue,omitempty"`
+}
+
+// PipelineResolver references a Pipeline through a Tekton remote resolver.
+type PipelineResolver struct {
+	// Resolver is one of bundles, git or cluster.
+	Resolver string `json:"resolver"`
+	// Params are passed to the resolver.
+	Params map[string]string `json:"params,omitempty"`
+	// Workspaces lists the workspaces the Pipeline declares.
+	Workspaces []string `json:"workspaces,omitempty"`
+}
+
+// Default returns the configuration used when no file is given.
+func Default() *Config {
+	return &Config{
+		PipelineName:       "master-ci-pipeline",
+		TektonEnabledLabel: "tekton-enabled",
+		ParamAliases: map[string]string{
+			pipeline.ImageRepoAddressParam: "image_repo_address",
+			pipeline.ImageRepoPathParam:    "image_repo_path",
+		},
+		Defaults: Defaults{
+			ImageRepoAddress:   "my-registry.io",
+			ImageRepoPath:      "my-project",
+			GitSecretName:      "git-credentials",
+			WorkspaceClaimName: "shared-data",
+		},
+		Listener: Listener{
+			GlobalProxyNamespace: "argocd",
+			GlobalProxyName:      "proxy-to-listener",
+			ServiceName:          "el-simple-listener",
+			Port:                 8080,
+		},
+		History: History{
+			SuccessfulRunsLimit: 5,
+			FailedRunsLimit:     3,
+		},
+		Requeue: Requeue{
+			PermissionError: metav1.Duration{Duration: 5 * time.Minute},
+			GitError:        metav1.Duration{Duration: 30 * time.Second},
+			NotFound:        metav1.Duration{Duration: 10 * time.Second},
+		},
+	}
+}
+
+// Load reads a YAML configuration file. Unset fields keep their Default values.
+// An empty path returns Default().
+func Load(path string) (*Config, error) {
+	if path == "" {
+		return Default(), nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config %s: %w", path, err)
+	}
+	cfg, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Parse decodes and validates YAML configuration data on top of Default().
+func Parse(data []byte) (*Config, error) {
+	cfg := Default()
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, fmt.Errorf("parse: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid: %w", err)
+	}
+	return cfg, nil
+}
+
+// Validate checks that every value can be used as-is by the controller.
+func (c *Config) Validate() error {
+	names := []struct{ field, value string }{
+		{"pipelineName", c.PipelineName},
+		{"defaults.gitSecretName", c.Defaults.GitSecretName},
+		{"defaults.workspaceClaimName", c.Defaults.WorkspaceClaimName},
+		{"listener.globalProxyNamespace", c.Listener.GlobalProxyNamespace},
+		{"listener.globalProxyName", c.Listener.GlobalProxyName},
+		{"listener.serviceName", c.Listener.ServiceName},
+	}
+	for _, n := range names {
+		if msgs := validation.IsDNS1123Subdomain(n.value); len(msgs) > 0 {
+			return fmt.Errorf("%s %q: %v", n.field, n.value, msgs)
+		}
+	}
+	if c.CatalogNamespace != "" {
+		if msgs := validation.IsDNS1123Label(c.CatalogNamespace); len(msgs) > 0 {
+			return fmt.Errorf("catalogNamespace %q: %v", c.CatalogNamespace, msgs)
+		}
+	}
+	if r := c.PipelineResolver; r != nil {
+		if !slices.Contains(pipeline.Resolvers, r.Resolver) {
+			return fmt.Errorf("pipelineResolver.resolver %q: must be one of %v", r.Resolver, pipeline.Resolvers)
+		}
+		if len(r.Params) == 0 {
+			return fmt.Errorf("pipelineResolver.params must not be empty")
+		}
+	}
+	for name, alias := range c.ParamAliases {
+		if name == "" || alias == "" || name == alias {
+			return fmt.Errorf("paramAliases %q: %q: names must be non-empty and differ", name, alias)
+		}
+	}
+	if msgs := validation.IsQualifiedName(c.TektonEnabledLabel); len(msgs) > 0 {
+		return fmt.Errorf("tektonEnabledLabel %q: %v", c.TektonEnabledLabel, msgs)
+	}
+	if c.Defaults.ImageRepoAddress == "" {
+		return fmt.Errorf("defaults.imageRepoAddress must not be empty")
+	}
+	if c.Defaults.ImageRepoPath == "" {
+		return fmt.Errorf("defaults.imageRepoPath must not be empty")
+	}
+	if msgs := validation.IsValidPortNum(int(c.Listener.Port)); len(msgs) > 0 {
+		return fmt.Errorf("listener.port %d: %v", c.Listener.Port, msgs)
+	}
+	if c.PipelineRunTimeout.Duration < 0 {
+		return fmt.Errorf("pipelineRunTimeout must not be negative, got %s", c.PipelineRunTimeout.Duration)
+	}
+	if c.History.SuccessfulRunsLimit < 0 || c.History.FailedRunsLimit < 0 {
+		return fmt.Errorf("history limits must not be negative")
+	}
+	durations := []struct {
+		field string
+		value metav1.Duration
+	}{
+		{"requeue.permissionError", c.Requeue.PermissionError},
+		{"requeue.gitError", c.Requeue.GitError},
+		{"requeue.notFound", c.Requeue.NotFound},
+	}
+	for _, d := range durations {
+		if d.value.Duration <= 0 {
+			return fmt.Errorf("%s must be positive, got %s", d.field, d.value.Duration)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+// File: pkg/config/config_test.go
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	return path
+}
+
+// replaceFile swaps the file atomically, like a ConfigMap volume update.
+func replaceFile(t *testing.T, path, content string) {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(content), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatalf("replace config: %v", err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	cfg, err := Load("")
+	assert.NoError(t, err)
+	assert.Equal(t, Default(), cfg)
+
+	cfg, err = Load(writeConfig(t, "defaults:\n  imageRepoAddress: harbor.example.com\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, "harbor.example.com", cfg.Defaults.ImageRepoAddress)
+	// 지정하지 않은 값은 기본값 유지
+	assert.Equal(t, "shared-data", cfg.Defaults.WorkspaceClaimName)
+
+	// paramAliases 는 기본 별칭에 추가됩니다.
+	cfg, err = Load(writeConfig(t, "paramAliases:\n  gitops-branch: gitops_branch\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, "gitops_branch", cfg.ParamAliases["gitops-branch"])
+	assert.Equal(t, "image_repo_address", cfg.ParamAliases["image-repo-address"])
+
+	_, err = Load(writeConfig(t, "defaults:\n  gitSecretName: Not_Valid\n"))
+	assert.Error(t, err)
+
+	_, err = Load(writeConfig(t, "unknownField: true\n"))
+	assert.Error(t, err, "unknown fields must be rejected")
+}
+
+func TestValidate(t *testing.T) {
+	cfg := Default()
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Listener.Port = 0
+	assert.Error(t, cfg.Validate())
+
+	cfg = Default()
+	cfg.Requeue.GitError.Duration = 0
+	assert.Error(t, cfg.Validate())
+
+	cfg = Default()
+	cfg.PipelineName = ""
+	assert.Error(t, cfg.Validate())
+
+	cfg = Default()
+	cfg.History.FailedRunsLimit = -1
+	assert.Error(t, cfg.Validate())
+
+	cfg = Default()
+	cfg.CatalogNamespace = "Not_Valid"
+	assert.Error(t, cfg.Validate())
+
+	cfg = Default()
+	cfg.PipelineResolver = &PipelineResolver{Resolver: "hub", Params: map[string]string{"name": "ci"}}
+	assert.Error(t, cfg.Validate())
+
+	cfg = Default()
+	cfg.ParamAliases["gitops-branch"] = "gitops-branch"
+	assert.Error(t, cfg.Validate())
+}
+
+func TestWatcher_ReloadsOnChange(t *testing.T) {
+	path := writeConfig(t, "pipelineName: first\n")
+	cfg, err := Load(path)
+	assert.NoError(t, err)
+	store := NewStore(cfg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = (&Watcher{Path: path, Store: store, Interval: 10 * time.Millisecond}).Start(ctx) }()
+
+	replaceFile(t, path, "pipelineName: second\n")
+	assert.Eventually(t, func() bool { return store.Get().PipelineName == "second" }, 2*time.Second, 10*time.Millisecond)
+
+	// 잘못된 설정은 무시하고 이전 설정 유지
+	replaceFile(t, path, "pipelineName: Not_Valid\n")
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, "second", store.Get().PipelineName)
+}
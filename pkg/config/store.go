@@ -0,0 +1,100 @@
+// File: pkg/config/store.go
+package config
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"reflect"
+	"sync/atomic"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// DefaultReloadInterval is how often a Watcher checks the configuration file.
+const DefaultReloadInterval = 10 * time.Second
+
+// Store holds the current configuration and is safe for concurrent use.
+// A nil *Store returns Default().
+type Store struct {
+	current atomic.Pointer[Config]
+}
+
+// NewStore returns a Store holding cfg.
+func NewStore(cfg *Config) *Store {
+	s := &Store{}
+	s.Set(cfg)
+	return s
+}
+
+// Get returns the current configuration. Callers must not modify it.
+func (s *Store) Get() *Config {
+	if s == nil {
+		return Default()
+	}
+	if cfg := s.current.Load(); cfg != nil {
+		return cfg
+	}
+	return Default()
+}
+
+// Set replaces the current configuration.
+func (s *Store) Set(cfg *Config) {
+	s.current.Store(cfg)
+}
+
+// Watcher reloads the configuration file into Store whenever its content
+// changes. Invalid content is logged and ignored so the last good
+// configuration stays in effect. Polling is used rather than inotify because
+// ConfigMap volumes are updated by swapping symlinks.
+type Watcher struct {
+	Path     string
+	Store    *Store
+	Interval time.Duration
+}
+
+// Start polls Path until ctx is cancelled. It implements manager.Runnable.
+func (w *Watcher) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("config-watcher")
+	interval := w.Interval
+	if interval <= 0 {
+		interval = DefaultReloadInterval
+	}
+	var last []byte
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		data, err := os.ReadFile(w.Path)
+		if err != nil {
+			logger.Error(err, "Failed to read controller configuration", "path", w.Path)
+			continue
+		}
+		if bytes.Equal(data, last) {
+			continue
+		}
+		last = data
+		cfg, err := Parse(data)
+		if err != nil {
+			logger.Error(err, "Ignoring invalid controller configuration, keeping the previous one", "path", w.Path)
+			continue
+		}
+		if reflect.DeepEqual(cfg, w.Store.Get()) {
+			continue
+		}
+		w.Store.Set(cfg)
+		logger.Info("Reloaded controller configuration", "path", w.Path)
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable; every replica
+// serves webhooks and must see the same configuration.
+func (w *Watcher) NeedLeaderElection() bool {
+	return false
+}
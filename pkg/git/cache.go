@@ -0,0 +1,155 @@
+// File: pkg/git/cache.go
+package git
+
+import (
+        "container/list"
+        "crypto/sha256"
+        "encoding/hex"
+        "fmt"
+        "strings"
+        "sync"
+        "time"
+
+        "github.com/go-git/go-git/v5/plumbing/transport"
+        "github.com/go-git/go-git/v5/plumbing/transport/http"
+        gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+        "golang.org/x/crypto/ssh"
+)
+
+const anonymousIdentity = "anonymous"
+
+type SHACacheEntry struct {
+        SHA       string
+        RefName   string
+        Timestamp time.Time
+}
+
+type shaCacheItem struct {
+        key   string
+        entry SHACacheEntry
+}
+
+// SHACache는 크기 제한이 있는 LRU + TTL 캐시입니다.
+// 키는 저장소 URL, 참조, 인증 주체로 구성되어 접근 권한이 다른 테넌트끼리 결과를 공유하지 않습니다.
+type SHACache struct {
+        mu         sync.Mutex
+        ttl        time.Duration
+        maxEntries int
+        ll         *list.List
+        items      map[string]*list.Element
+}
+
+// NewSHACache는 최대 maxEntries 개의 항목을 ttl 동안 보관하는 캐시를 생성합니다.
+func NewSHACache(maxEntries int, ttl time.Duration) *SHACache {
+        return &SHACache{
+                ttl:        ttl,
+                maxEntries: maxEntries,
+                ll:         list.New(),
+                items:      make(map[string]*list.Element),
+        }
+}
+
+// Get은 만료되지 않은 캐시 항목을 반환합니다. 만료된 항목은 제거됩니다.
+func (c *SHACache) Get(repoURL string, ref Ref, auth transport.AuthMethod) (SHACacheEntry, bool) {
+        if c == nil {
+                return SHACacheEntry{}, false
+        }
+        key := shaCacheKey(repoURL, ref, auth)
+
+        c.mu.Lock()
+        defer c.mu.Unlock()
+        el, ok := c.items[key]
+        if !ok {
+                shaCacheMisses.Inc()
+                return SHACacheEntry{}, false
+        }
+        item := el.Value.(*shaCacheItem)
+        if time.Since(item.entry.Timestamp) >= c.ttl {
+                c.removeElement(el, evictionExpired)
+                shaCacheMisses.Inc()
+                return SHACacheEntry{}, false
+        }
+        c.ll.MoveToFront(el)
+        shaCacheHits.Inc()
+        return item.entry, true
+}
+
+// Add는 항목을 저장하고, 최대 크기를 넘으면 가장 오래 사용되지 않은 항목을 제거합니다.
+func (c *SHACache) Add(repoURL string, ref Ref, auth transport.AuthMethod, entry SHACacheEntry) {
+        if c == nil {
+                return
+        }
+        key := shaCacheKey(repoURL, ref, auth)
+
+        c.mu.Lock()
+        defer c.mu.Unlock()
+        if el, ok := c.items[key]; ok {
+                el.Value.(*shaCacheItem).entry = entry
+                c.ll.MoveToFront(el)
+                return
+        }
+        c.items[key] = c.ll.PushFront(&shaCacheItem{key: key, entry: entry})
+        for c.maxEntries > 0 && c.ll.Len() > c.maxEntries {
+                c.removeElement(c.ll.Back(), evictionCapacity)
+        }
+}
+
+// Invalidate는 인증 주체와 관계없이 repoURL/ref 의 모든 캐시 항목을 제거합니다.
+func (c *SHACache) Invalidate(repoURL string, ref Ref) {
+        if c == nil {
+                return
+        }
+        prefix := shaCacheKeyPrefix(repoURL, ref)
+
+        c.mu.Lock()
+        defer c.mu.Unlock()
+        for key, el := range c.items {
+                if strings.HasPrefix(key, prefix) {
+                        c.removeElement(el, evictionInvalidated)
+                }
+        }
+}
+
+// Len은 현재 캐시 항목 수를 반환합니다.
+func (c *SHACache) Len() int {
+        if c == nil {
+                return 0
+        }
+        c.mu.Lock()
+        defer c.mu.Unlock()
+        return c.ll.Len()
+}
+
+func (c *SHACache) removeElement(el *list.Element, reason string) {
+        c.ll.Remove(el)
+        delete(c.items, el.Value.(*shaCacheItem).key)
+        shaCacheEvictions.WithLabelValues(reason).Inc()
+}
+
+func shaCacheKeyPrefix(repoURL string, ref Ref) string {
+        return fmt.Sprintf("%s|%s|", repoURL, ref)
+}
+
+func shaCacheKey(repoURL string, ref Ref, auth transport.AuthMethod) string {
+        return shaCacheKeyPrefix(repoURL, ref) + authIdentity(auth)
+}
+
+// authIdentity는 캐시 키에 사용할 인증 주체를 반환합니다. 비밀값 자체는 해시로만 포함됩니다.
+func authIdentity(auth transport.AuthMethod) string {
+        switch a := auth.(type) {
+        case nil:
+                return anonymousIdentity
+        case *http.BasicAuth:
+                return "basic:" + a.Username + ":" + secretDigest(a.Password)
+        case *http.TokenAuth:
+                return "token:" + secretDigest(a.Token)
+        case *gitssh.PublicKeys:
+                return "ssh:" + a.User + ":" + ssh.FingerprintSHA256(a.Signer.PublicKey())
+        }
+        return auth.Name() + ":" + secretDigest(auth.String())
+}
+
+func secretDigest(s string) string {
+        sum := sha256.Sum256([]byte(s))
+        return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,68 @@
+// File: pkg/git/cache_test.go
+package git
+
+import (
+        "testing"
+        "time"
+
+        "github.com/go-git/go-git/v5/plumbing/transport/http"
+)
+
+func TestSHACache_LRUEviction(t *testing.T) {
+        c := NewSHACache(2, time.Minute)
+        now := time.Now()
+        c.Add("repo", Ref{Branch: "a"}, nil, SHACacheEntry{SHA: "a", Timestamp: now})
+        c.Add("repo", Ref{Branch: "b"}, nil, SHACacheEntry{SHA: "b", Timestamp: now})
+        // a 를 최근 사용으로 만들어 b 가 먼저 제거되도록 함
+        if _, ok := c.Get("repo", Ref{Branch: "a"}, nil); !ok {
+                t.Fatalf("expected a to be cached")
+        }
+        c.Add("repo", Ref{Branch: "c"}, nil, SHACacheEntry{SHA: "c", Timestamp: now})
+
+        if c.Len() != 2 {
+                t.Errorf("expected 2 entries, got %d", c.Len())
+        }
+        if _, ok := c.Get("repo", Ref{Branch: "b"}, nil); ok {
+                t.Errorf("expected least recently used entry b to be evicted")
+        }
+        if _, ok := c.Get("repo", Ref{Branch: "a"}, nil); !ok {
+                t.Errorf("expected a to survive eviction")
+        }
+}
+
+func TestSHACache_TTL(t *testing.T) {
+        c := NewSHACache(10, time.Minute)
+        c.Add("repo", Ref{Branch: "main"}, nil, SHACacheEntry{SHA: "old", Timestamp: time.Now().Add(-2 * time.Minute)})
+        if _, ok := c.Get("repo", Ref{Branch: "main"}, nil); ok {
+                t.Errorf("expected expired entry to miss")
+        }
+        if c.Len() != 0 {
+                t.Errorf("expected expired entry to be removed, got %d entries", c.Len())
+        }
+}
+
+func TestSHACache_AuthIdentity(t *testing.T) {
+        c := NewSHACache(10, time.Minute)
+        ref := Ref{Branch: "main"}
+        tenantA := &http.BasicAuth{Username: "oauth2", Password: "token-a"}
+        tenantB := &http.BasicAuth{Username: "oauth2", Password: "token-b"}
+        c.Add("repo", ref, tenantA, SHACacheEntry{SHA: "a", Timestamp: time.Now()})
+
+        if _, ok := c.Get("repo", ref, tenantB); ok {
+                t.Errorf("expected a different credential not to share the cache entry")
+        }
+        if _, ok := c.Get("repo", ref, nil); ok {
+                t.Errorf("expected anonymous lookup not to share the cache entry")
+        }
+        if e, ok := c.Get("repo", ref, &http.BasicAuth{Username: "oauth2", Password: "token-a"}); !ok || e.SHA != "a" {
+                t.Errorf("expected the same credential to hit, got %v, %v", e, ok)
+        }
+
+        // Invalidate는 인증 주체와 관계없이 모두 제거
+        c.Add("repo", ref, tenantB, SHACacheEntry{SHA: "b", Timestamp: time.Now()})
+        c.Add("repo", Ref{Branch: "dev"}, tenantB, SHACacheEntry{SHA: "d", Timestamp: time.Now()})
+        c.Invalidate("repo", ref)
+        if c.Len() != 1 {
+                t.Errorf("expected only the dev entry to remain, got %d entries", c.Len())
+        }
+}
@@ -0,0 +1,52 @@
+// File: pkg/git/metrics.go
+package git
+
+import (
+        "github.com/prometheus/client_golang/prometheus"
+        "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// SHA 캐시 제거 사유
+const (
+        evictionCapacity    = "capacity"
+        evictionExpired     = "expired"
+        evictionInvalidated = "invalidated"
+)
+
+var (
+        shaCacheHits = prometheus.NewCounter(
+                prometheus.CounterOpts{
+                        Namespace: "tekton_controller",
+                        Name:      "git_sha_cache_hits_total",
+                        Help:      "Number of Git SHA lookups served from the cache.",
+                },
+        )
+        shaCacheMisses = prometheus.NewCounter(
+                prometheus.CounterOpts{
+                        Namespace: "tekton_controller",
+                        Name:      "git_sha_cache_misses_total",
+                        Help:      "Number of Git SHA lookups that were not in the cache or had expired.",
+                },
+        )
+        shaCacheEvictions = prometheus.NewCounterVec(
+                prometheus.CounterOpts{
+                        Namespace: "tekton_controller",
+                        Name:      "git_sha_cache_evictions_total",
+                        Help:      "Number of Git SHA cache entries removed, by reason.",
+                },
+                []string{"reason"},
+        )
+        remoteResolveDuration = prometheus.NewHistogramVec(
+                prometheus.HistogramOpts{
+                        Namespace: "tekton_controller",
+                        Name:      "git_remote_resolve_duration_seconds",
+                        Help:      "Duration of remote Git ref listings, by result.",
+                        Buckets:   prometheus.DefBuckets,
+                },
+                []string{"result"},
+        )
+)
+
+func init() {
+        metrics.Registry.MustRegister(shaCacheHits, shaCacheMisses, shaCacheEvictions, remoteResolveDuration)
+}
@@ -0,0 +1,126 @@
+// File: pkg/git/ref.go
+package git
+
+import (
+        "fmt"
+        "regexp"
+        "strings"
+
+        "github.com/blang/semver/v4"
+        "github.com/go-git/go-git/v5/plumbing"
+
+        workloadv1alpha1 "tekton-controller/api/v1alpha1"
+)
+
+const (
+        peeledSuffix = "^{}"
+        semverPrefix = "semver:"
+)
+
+var commitSHARegexp = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// Ref는 해석할 Git 참조입니다. Branch, Tag, Commit, Semver 중 정확히 하나만 지정합니다.
+type Ref struct {
+        Branch string
+        Tag    string
+        // Commit은 40자리 전체 커밋 SHA 입니다. 원격 조회 없이 그대로 사용됩니다.
+        Commit string
+        // Semver는 원격 태그 중 가장 높은 일치 버전을 고르는 범위입니다. (예: ">=1.2.0 <2.0.0")
+        Semver string
+}
+
+// RefFromSpec은 Workload 의 spec.source.git.ref 를 Ref 로 변환합니다.
+func RefFromSpec(ref workloadv1alpha1.GitRef) Ref {
+        return Ref{Branch: ref.Branch, Tag: ref.Tag, Commit: ref.Commit, Semver: ref.Semver}
+}
+
+// ResolvedRef는 해석된 참조 이름과 커밋 SHA 입니다.
+type ResolvedRef struct {
+        // Name은 refs/heads/<branch>, refs/tags/<tag> 또는 고정된 커밋 SHA 입니다.
+        Name string
+        SHA  string
+}
+
+// ShortName은 refs/heads/, refs/tags/ 접두사를 제거한 이름을 반환합니다.
+func (r ResolvedRef) ShortName() string {
+        return plumbing.ReferenceName(r.Name).Short()
+}
+
+// Validate는 정확히 하나의 참조 종류가 올바른 형식으로 지정되었는지 확인합니다.
+func (ref Ref) Validate() error {
+        set := 0
+        for _, v := range []string{ref.Branch, ref.Tag, ref.Commit, ref.Semver} {
+                if v != "" {
+                        set++
+                }
+        }
+        if set != 1 {
+                return fmt.Errorf("exactly one of branch, tag, commit or semver must be set")
+        }
+        if ref.Commit != "" && !commitSHARegexp.MatchString(ref.Commit) {
+                return fmt.Errorf("commit %q must be a full 40 character lowercase hex SHA", ref.Commit)
+        }
+        if ref.Semver != "" {
+                if _, err := semver.ParseRange(ref.Semver); err != nil {
+                        return fmt.Errorf("invalid semver range %q: %w", ref.Semver, err)
+                }
+        }
+        return nil
+}
+
+// String은 캐시 키와 로그에 사용되는 참조 표현을 반환합니다.
+func (ref Ref) String() string {
+        switch {
+        case ref.Branch != "":
+                return plumbing.NewBranchReferenceName(ref.Branch).String()
+        case ref.Tag != "":
+                return plumbing.NewTagReferenceName(ref.Tag).String()
+        case ref.Commit != "":
+                return ref.Commit
+        case ref.Semver != "":
+                return semverPrefix + ref.Semver
+        }
+        return ""
+}
+
+// selectSemverTag는 원격 참조 목록에서 범위에 맞는 가장 높은 버전의 태그를 고릅니다.
+// 주석(annotated) 태그는 ^{} 로 벗겨진 커밋 SHA를 사용합니다.
+func selectSemverTag(refs []*plumbing.Reference, constraint string) (ResolvedRef, error) {
+        rng, err := semver.ParseRange(constraint)
+        if err != nil {
+                return ResolvedRef{}, fmt.Errorf("invalid semver range %q: %w", constraint, err)
+        }
+
+        peeled := make(map[string]string)
+        for _, r := range refs {
+                if name := r.Name().String(); strings.HasSuffix(name, peeledSuffix) {
+                        peeled[strings.TrimSuffix(name, peeledSuffix)] = r.Hash().String()
+                }
+        }
+
+        var best *semver.Version
+        var result ResolvedRef
+        for _, r := range refs {
+                name := r.Name()
+                if !name.IsTag() || strings.HasSuffix(name.String(), peeledSuffix) {
+                        continue
+                }
+                v, err := semver.ParseTolerant(name.Short())
+                if err != nil || !rng(v) {
+                        continue
+                }
+                if best != nil && !v.GT(*best) {
+                        continue
+                }
+                best = &v
+                sha := r.Hash().String()
+                if p, ok := peeled[name.String()]; ok {
+                        sha = p
+                }
+                result = ResolvedRef{Name: name.String(), SHA: sha}
+        }
+        if best == nil {
+                return ResolvedRef{}, fmt.Errorf("no tag matches semver range %q", constraint)
+        }
+        return result, nil
+}
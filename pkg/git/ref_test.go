@@ -0,0 +1,61 @@
+// File: pkg/git/ref_test.go
+package git
+
+import (
+        "testing"
+
+        "github.com/go-git/go-git/v5/plumbing"
+        "github.com/stretchr/testify/assert"
+)
+
+func TestRefValidate(t *testing.T) {
+        sha := "0123456789abcdef0123456789abcdef01234567"
+        testCases := []struct {
+                name    string
+                ref     Ref
+                wantErr bool
+        }{
+                {name: "branch", ref: Ref{Branch: "main"}},
+                {name: "tag", ref: Ref{Tag: "v1.0.0"}},
+                {name: "commit", ref: Ref{Commit: sha}},
+                {name: "semver", ref: Ref{Semver: ">=1.0.0 <2.0.0"}},
+                {name: "없음", ref: Ref{}, wantErr: true},
+                {name: "두 개 지정", ref: Ref{Branch: "main", Tag: "v1"}, wantErr: true},
+                {name: "짧은 커밋", ref: Ref{Commit: "abc1234"}, wantErr: true},
+                {name: "잘못된 semver", ref: Ref{Semver: "not-a-range"}, wantErr: true},
+        }
+        for _, tc := range testCases {
+                t.Run(tc.name, func(t *testing.T) {
+                        err := tc.ref.Validate()
+                        if tc.wantErr {
+                                assert.Error(t, err)
+                        } else {
+                                assert.NoError(t, err)
+                        }
+                })
+        }
+}
+
+func TestSelectSemverTag(t *testing.T) {
+        refs := []*plumbing.Reference{
+                plumbing.NewReferenceFromStrings("refs/heads/main", "1111111111111111111111111111111111111111"),
+                plumbing.NewReferenceFromStrings("refs/tags/v1.0.0", "2222222222222222222222222222222222222222"),
+                plumbing.NewReferenceFromStrings("refs/tags/v1.2.0", "3333333333333333333333333333333333333333"),
+                plumbing.NewReferenceFromStrings("refs/tags/v1.2.0^{}", "4444444444444444444444444444444444444444"),
+                plumbing.NewReferenceFromStrings("refs/tags/v2.0.0", "5555555555555555555555555555555555555555"),
+                plumbing.NewReferenceFromStrings("refs/tags/latest", "6666666666666666666666666666666666666666"),
+        }
+
+        got, err := selectSemverTag(refs, ">=1.0.0 <2.0.0")
+        assert.NoError(t, err)
+        // 주석 태그는 벗겨진 커밋 SHA 로 해석되어야 합니다.
+        assert.Equal(t, ResolvedRef{Name: "refs/tags/v1.2.0", SHA: "4444444444444444444444444444444444444444"}, got)
+        assert.Equal(t, "v1.2.0", got.ShortName())
+
+        got, err = selectSemverTag(refs, ">=2.0.0")
+        assert.NoError(t, err)
+        assert.Equal(t, "5555555555555555555555555555555555555555", got.SHA)
+
+        _, err = selectSemverTag(refs, ">=3.0.0")
+        assert.Error(t, err)
+}
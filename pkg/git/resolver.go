@@ -6,51 +6,99 @@ import (
         "fmt"
         "os"
         "strconv"
+        "strings"
         "sync"
         "time"
 
         git "github.com/go-git/go-git/v5"
         "github.com/go-git/go-git/v5/config"
         "github.com/go-git/go-git/v5/plumbing"
+        "github.com/go-git/go-git/v5/plumbing/transport"
         "github.com/go-git/go-git/v5/plumbing/transport/http"
+        gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
         "github.com/go-git/go-git/v5/storage/memory"
+        "golang.org/x/crypto/ssh"
+        "golang.org/x/sync/singleflight"
         corev1 "k8s.io/api/core/v1"
+
+        "tekton-controller/pkg/util"
 )
 
 const (
-        GitSHACacheTTLKey        = "GIT_SHA_CACHE_TTL_SECONDS"
-        DefaultGitSHACacheTTLSeconds = 60 // 1 minute
-        UsernameField                = "username"
-        PasswordField                = "password"
+        GitSHACacheTTLKey              = "GIT_SHA_CACHE_TTL_SECONDS"
+        DefaultGitSHACacheTTLSeconds   = 60 // 1 minute
+        GitSHACacheMaxEntriesKey       = "GIT_SHA_CACHE_MAX_ENTRIES"
+        DefaultGitSHACacheMaxEntries   = 1024
+        GitMaxConcurrentPerHostKey     = "GIT_MAX_CONCURRENT_PER_HOST"
+        DefaultGitMaxConcurrentPerHost = 4
+        UsernameField                  = "username"
+        PasswordField                  = "password"
+        SSHPrivateKeyField             = corev1.SSHAuthPrivateKey // "ssh-privatekey"
+        KnownHostsField                = "known_hosts"
+
+        // TektonGitAnnotationPrefix는 Tekton 방식의 Git 인증 Secret 어노테이션 접두사입니다.
+        // (예: tekton.dev/git-0: https://gitlab.com, tekton.dev/git-1: github.com)
+        TektonGitAnnotationPrefix = "tekton.dev/git-"
+
+        defaultSSHUser = "git"
 )
 
-type SHACacheEntry struct {
-        SHA       string
-        Timestamp time.Time
-}
+// remoteResolveTimeout은 여러 호출자가 공유하는 원격 조회 하나의 최대 시간입니다.
+const remoteResolveTimeout = time.Minute
+
+type refLister func(ctx context.Context, repoURL string, auth transport.AuthMethod) ([]*plumbing.Reference, error)
 
 type Resolver struct {
-        SHACache    map[string]SHACacheEntry
-        SHAMutex    sync.RWMutex
+        // Cache가 nil 이면 매번 원격을 조회합니다.
+        Cache       *SHACache
         SHACacheTTL time.Duration
+        // MaxConcurrentPerHost는 호스트별 동시 원격 조회 수 제한입니다. 0 이하이면 제한하지 않습니다.
+        MaxConcurrentPerHost int
+
+        // inflight는 같은 repo/ref/인증에 대한 동시 원격 조회를 하나로 합칩니다.
+        inflight singleflight.Group
+        hostMu   sync.Mutex
+        hostSems map[string]chan struct{}
+        // listRefs는 테스트에서 원격 조회를 대체하기 위한 것입니다. nil 이면 listRemoteRefs 를 사용합니다.
+        listRefs refLister
 }
 
 // NewResolver는 새로운 Git Resolver를 생성합니다.
 func NewResolver() *Resolver {
-        ttl := time.Duration(DefaultGitSHACacheTTLSeconds) * time.Second
-        if v := os.Getenv(GitSHACacheTTLKey); v != "" {
-                if secs, err := strconv.Atoi(v); err == nil && secs > 0 {
-                        ttl = time.Duration(secs) * time.Second
-                }
-        }
+        ttl := time.Duration(positiveIntFromEnv(GitSHACacheTTLKey, DefaultGitSHACacheTTLSeconds)) * time.Second
+        maxEntries := positiveIntFromEnv(GitSHACacheMaxEntriesKey, DefaultGitSHACacheMaxEntries)
         return &Resolver{
-                SHACache:    make(map[string]SHACacheEntry),
-                SHACacheTTL: ttl,
+                Cache:                NewSHACache(maxEntries, ttl),
+                SHACacheTTL:          ttl,
+                MaxConcurrentPerHost: positiveIntFromEnv(GitMaxConcurrentPerHostKey, DefaultGitMaxConcurrentPerHost),
+        }
+}
+
+func positiveIntFromEnv(key string, def int) int {
+        if v := os.Getenv(key); v != "" {
+                if n, err := strconv.Atoi(v); err == nil && n > 0 {
+                        return n
+                }
         }
+        return def
 }
 
-// GetGitAuthFromSecret은 Secret에서 Git 인증 정보를 읽어옵니다.
-func GetGitAuthFromSecret(secret *corev1.Secret) (*http.BasicAuth, error) {
+// GetGitAuthFromSecret은 Secret에서 repoURL 용 Git 인증 정보를 읽어옵니다.
+//   - ssh-privatekey 키가 있으면 (kubernetes.io/ssh-auth) SSH 공개키 인증을 사용하고,
+//     known_hosts 키가 있으면 엄격한 호스트 키 검사를 적용합니다.
+//   - 그 외에는 username/password (kubernetes.io/basic-auth) 로 HTTP Basic 인증을 사용합니다.
+//
+// Secret에 tekton.dev/git-N 어노테이션이 있는데 repoURL 호스트와 일치하는 것이 없으면
+// 다른 호스트용 Secret이므로 nil, nil 을 반환합니다.
+func GetGitAuthFromSecret(secret *corev1.Secret, repoURL string) (transport.AuthMethod, error) {
+        if !matchesTektonGitAnnotations(secret, repoURL) {
+                return nil, nil
+        }
+
+        if key := secret.Data[SSHPrivateKeyField]; len(key) > 0 {
+                return sshAuthFromSecret(key, secret.Data[KnownHostsField], repoURL)
+        }
+
         user := string(secret.Data[UsernameField])
         pass := string(secret.Data[PasswordField])
         if user == "" || pass == "" {
@@ -59,51 +107,240 @@ func GetGitAuthFromSecret(secret *corev1.Secret) (*http.BasicAuth, error) {
         return &http.BasicAuth{Username: user, Password: pass}, nil
 }
 
-// ResolveGitSHA는 Git 브랜치의 최신 SHA를 확인합니다. 캐시를 활용합니다.
-func (r *Resolver) ResolveGitSHA(ctx context.Context, repoURL, branch string, auth *http.BasicAuth) (string, error) {
-        cacheKey := fmt.Sprintf("%s|%s", repoURL, branch)
-        now := time.Now()
+// matchesTektonGitAnnotations는 tekton.dev/git-N 어노테이션이 없거나,
+// 그 중 하나가 repoURL 의 호스트와 일치하면 true 를 반환합니다.
+func matchesTektonGitAnnotations(secret *corev1.Secret, repoURL string) bool {
+        repoHost := repoHostOf(repoURL)
+        annotated := false
+        for k, v := range secret.GetAnnotations() {
+                if !strings.HasPrefix(k, TektonGitAnnotationPrefix) {
+                        continue
+                }
+                annotated = true
+                if repoHostOf(v) == repoHost {
+                        return true
+                }
+        }
+        return !annotated
+}
+
+// ValidateRepoURL은 원격 조회가 가능한 저장소 URL 인지 확인합니다.
+// http(s)://, ssh://, git:// 및 scp 형식(git@host:group/repo.git)을 허용합니다.
+func ValidateRepoURL(repoURL string) error {
+        if repoURL == "" || strings.TrimSpace(repoURL) != repoURL {
+                return fmt.Errorf("repository URL must be non-empty without surrounding whitespace")
+        }
+        ep, err := transport.NewEndpoint(repoURL)
+        if err != nil {
+                return fmt.Errorf("invalid repository URL: %w", err)
+        }
+        switch ep.Protocol {
+        case "http", "https", "ssh", "git":
+        default:
+                return fmt.Errorf("unsupported repository URL scheme %q", ep.Protocol)
+        }
+        if ep.Host == "" {
+                return fmt.Errorf("repository URL has no host")
+        }
+        if strings.Trim(ep.Path, "/") == "" {
+                return fmt.Errorf("repository URL has no repository path")
+        }
+        return nil
+}
 
-        r.SHAMutex.RLock()
-        entry, cached := r.SHACache[cacheKey]
-        r.SHAMutex.RUnlock()
+func repoHostOf(u string) string {
+        host, _, _ := strings.Cut(util.NormalizeRepoURL(u), "/")
+        return host
+}
+
+// sshAuthFromSecret은 PEM 개인키로 SSH 인증을 구성합니다.
+// knownHosts 가 비어 있으면 호스트 키를 검사하지 않습니다.
+func sshAuthFromSecret(key, knownHosts []byte, repoURL string) (transport.AuthMethod, error) {
+        user := defaultSSHUser
+        if ep, err := transport.NewEndpoint(repoURL); err == nil && ep.User != "" {
+                user = ep.User
+        }
+        auth, err := gitssh.NewPublicKeys(user, key, "")
+        if err != nil {
+                return nil, fmt.Errorf("failed to parse ssh private key: %w", err)
+        }
 
-        if cached && now.Sub(entry.Timestamp) < r.SHACacheTTL {
-                return entry.SHA, nil
+        if len(knownHosts) == 0 {
+                auth.HostKeyCallback = ssh.InsecureIgnoreHostKey()
+                return auth, nil
         }
+        cb, err := knownHostsCallback(knownHosts)
+        if err != nil {
+                return nil, err
+        }
+        auth.HostKeyCallback = cb
+        return auth, nil
+}
 
-        // Not cached or expired, resolve from remote
-        storer := memory.NewStorage()
-        repo, err := git.Init(storer, nil)
+// knownHostsCallback은 known_hosts 내용으로 엄격한 HostKeyCallback 을 만듭니다.
+// go-git 은 파일 경로만 받으므로 임시 파일에 기록한 뒤 메모리로 읽어들이고 삭제합니다.
+func knownHostsCallback(knownHosts []byte) (ssh.HostKeyCallback, error) {
+        f, err := os.CreateTemp("", "known_hosts-*")
+        if err != nil {
+                return nil, fmt.Errorf("failed to create known_hosts file: %w", err)
+        }
+        defer os.Remove(f.Name())
+        if _, err := f.Write(knownHosts); err != nil {
+                f.Close()
+                return nil, fmt.Errorf("failed to write known_hosts file: %w", err)
+        }
+        if err := f.Close(); err != nil {
+                return nil, fmt.Errorf("failed to write known_hosts file: %w", err)
+        }
+        cb, err := gitssh.NewKnownHostsCallback(f.Name())
         if err != nil {
-                return "", fmt.Errorf("failed to init git repo: %w", err)
+                return nil, fmt.Errorf("failed to parse known_hosts: %w", err)
+        }
+        return cb, nil
+}
+
+// Invalidate는 repoURL/ref 의 캐시 항목을 모든 인증 주체에 대해 제거합니다.
+// 웹훅으로 새 커밋이 알려졌을 때 다음 Resolve가 원격을 다시 조회하도록 합니다.
+func (r *Resolver) Invalidate(repoURL string, ref Ref) {
+        r.Cache.Invalidate(repoURL, ref)
+}
+
+// ResolveGitSHA는 Git 브랜치의 최신 SHA를 확인합니다. 캐시를 활용합니다.
+func (r *Resolver) ResolveGitSHA(ctx context.Context, repoURL, branch string, auth transport.AuthMethod) (string, error) {
+        resolved, err := r.Resolve(ctx, repoURL, Ref{Branch: branch}, auth)
+        return resolved.SHA, err
+}
+
+// Resolve는 브랜치, 태그, 커밋 또는 semver 범위를 참조 이름과 커밋 SHA로 해석합니다. 캐시를 활용합니다.
+func (r *Resolver) Resolve(ctx context.Context, repoURL string, ref Ref, auth transport.AuthMethod) (ResolvedRef, error) {
+        if err := ref.Validate(); err != nil {
+                return ResolvedRef{}, err
+        }
+        // 커밋은 이미 고정되어 있으므로 원격 조회가 필요 없습니다.
+        if ref.Commit != "" {
+                return ResolvedRef{Name: ref.Commit, SHA: ref.Commit}, nil
         }
 
-        _, err = repo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{repoURL}})
-        if err != nil && err != git.ErrRemoteExists {
-                return "", fmt.Errorf("failed to add remote: %w", err)
+        if entry, ok := r.Cache.Get(repoURL, ref, auth); ok {
+                return ResolvedRef{Name: entry.RefName, SHA: entry.SHA}, nil
         }
 
-        if err := repo.FetchContext(ctx, &git.FetchOptions{
-                RemoteName: "origin",
-                Depth:      1,
-                RefSpecs:   []config.RefSpec{config.RefSpec(fmt.Sprintf("+refs/heads/%s:refs/heads/%s", branch, branch))},
-                Auth:       auth,
-                Tags:       git.NoTags,
-        }); err != nil && err != git.NoErrAlreadyUpToDate {
-                return "", fmt.Errorf("failed to fetch branch %s: %w", branch, err)
+        // Not cached or expired, resolve from remote.
+        // 동시에 들어온 같은 키의 호출은 하나의 원격 조회 결과(또는 에러)를 공유합니다.
+        // 먼저 들어온 호출자의 취소가 다른 호출자에게 전파되지 않도록 취소와 분리된 컨텍스트를 사용합니다.
+        ch := r.inflight.DoChan(shaCacheKey(repoURL, ref, auth), func() (interface{}, error) {
+                remoteCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), remoteResolveTimeout)
+                defer cancel()
+                return r.resolveRemote(remoteCtx, repoURL, ref, auth)
+        })
+        select {
+        case res := <-ch:
+                if res.Err != nil {
+                        return ResolvedRef{}, res.Err
+                }
+                return res.Val.(ResolvedRef), nil
+        case <-ctx.Done():
+                return ResolvedRef{}, ctx.Err()
+        }
+}
+
+// resolveRemote는 호스트별 동시 실행 수 제한 안에서 원격 참조를 조회하고 결과를 캐시에 저장합니다.
+func (r *Resolver) resolveRemote(ctx context.Context, repoURL string, ref Ref, auth transport.AuthMethod) (ResolvedRef, error) {
+        release, err := r.acquireHost(ctx, repoHostOf(repoURL))
+        if err != nil {
+                return ResolvedRef{}, err
         }
+        defer release()
 
-        ref, err := repo.Reference(plumbing.NewBranchReferenceName(branch), true)
+        now := time.Now()
+        list := r.listRefs
+        if list == nil {
+                list = listRemoteRefs
+        }
+        refs, err := list(ctx, repoURL, auth)
+        if err != nil {
+                return ResolvedRef{}, err
+        }
+        var resolved ResolvedRef
+        if ref.Semver != "" {
+                resolved, err = selectSemverTag(refs, ref.Semver)
+        } else {
+                resolved, err = findRef(refs, plumbing.ReferenceName(ref.String()))
+        }
         if err != nil {
-                return "", fmt.Errorf("failed to get branch ref: %w", err)
+                return ResolvedRef{}, err
+        }
+
+        r.Cache.Add(repoURL, ref, auth, SHACacheEntry{SHA: resolved.SHA, RefName: resolved.Name, Timestamp: now})
+
+        return resolved, nil
+}
+
+// acquireHost는 host 의 동시 실행 슬롯을 얻을 때까지 기다립니다. 반환된 함수로 슬롯을 반납합니다.
+func (r *Resolver) acquireHost(ctx context.Context, host string) (func(), error) {
+        if r.MaxConcurrentPerHost <= 0 {
+                return func() {}, nil
+        }
+        r.hostMu.Lock()
+        if r.hostSems == nil {
+                r.hostSems = make(map[string]chan struct{})
+        }
+        sem, ok := r.hostSems[host]
+        if !ok {
+                sem = make(chan struct{}, r.MaxConcurrentPerHost)
+                r.hostSems[host] = sem
+        }
+        r.hostMu.Unlock()
+
+        select {
+        case sem <- struct{}{}:
+                return func() { <-sem }, nil
+        case <-ctx.Done():
+                return nil, fmt.Errorf("waiting for a free connection slot to %s: %w", host, ctx.Err())
         }
+}
 
-        sha := ref.Hash().String()
+// listRemoteRefs는 git ls-remote 와 같이 원격이 광고하는 참조 목록만 조회합니다.
+// 객체(packfile)는 전송되지 않으며, 주석 태그는 ^{} 항목으로 함께 받습니다.
+func listRemoteRefs(ctx context.Context, repoURL string, auth transport.AuthMethod) ([]*plumbing.Reference, error) {
+        start := time.Now()
+        remote := git.NewRemote(memory.NewStorage(), &config.RemoteConfig{Name: "origin", URLs: []string{repoURL}})
+        refs, err := remote.ListContext(ctx, &git.ListOptions{Auth: auth, PeelingOption: git.AppendPeeled})
+        result := "success"
+        if err != nil {
+                result = "error"
+        }
+        remoteResolveDuration.WithLabelValues(result).Observe(time.Since(start).Seconds())
+        if err != nil {
+                return nil, fmt.Errorf("failed to list remote refs: %w", err)
+        }
+        return refs, nil
+}
 
-        r.SHAMutex.Lock()
-        r.SHACache[cacheKey] = SHACacheEntry{SHA: sha, Timestamp: now}
-        r.SHAMutex.Unlock()
+// CheckConnectivity는 repoURL 의 참조 목록을 조회해 원격에 접근 가능한지 확인합니다. 캐시를 사용하지 않습니다.
+func CheckConnectivity(ctx context.Context, repoURL string, auth transport.AuthMethod) error {
+        _, err := listRemoteRefs(ctx, repoURL, auth)
+        return err
+}
 
-        return sha, nil
+// findRef는 참조 목록에서 refName 의 커밋 SHA를 찾습니다. 주석 태그는 ^{} 로 벗겨진 SHA를 사용합니다.
+func findRef(refs []*plumbing.Reference, refName plumbing.ReferenceName) (ResolvedRef, error) {
+        var hash plumbing.Hash
+        found := false
+        for _, r := range refs {
+                switch r.Name().String() {
+                case refName.String():
+                        if !found {
+                                hash = r.Hash()
+                                found = true
+                        }
+                case refName.String() + peeledSuffix:
+                        hash = r.Hash()
+                        found = true
+                }
+        }
+        if !found {
+                return ResolvedRef{}, fmt.Errorf("ref %s not found in remote", refName)
+        }
+        return ResolvedRef{Name: refName.String(), SHA: hash.String()}, nil
 }
@@ -0,0 +1,156 @@
+// File: pkg/git/resolver_bench_test.go
+package git
+
+import (
+        "context"
+        "crypto/rand"
+        "fmt"
+        "net/http/cgi"
+        "net/http/httptest"
+        "os"
+        "os/exec"
+        "path/filepath"
+        "testing"
+        "time"
+
+        git "github.com/go-git/go-git/v5"
+        "github.com/go-git/go-git/v5/config"
+        "github.com/go-git/go-git/v5/plumbing"
+        "github.com/go-git/go-git/v5/plumbing/object"
+        "github.com/go-git/go-git/v5/storage/memory"
+)
+
+const (
+        benchRepoFiles    = 200
+        benchRepoFileSize = 16 << 10
+        benchTag          = "v1.0.0"
+)
+
+// testRepo는 로컬 bare 저장소와 master 및 주석 태그 v1.0.0 이 가리키는 커밋 SHA 입니다.
+type testRepo struct {
+        Dir     string
+        HeadSHA string
+}
+
+// newBareRepo는 무작위 파일로 커밋을 만들고 주석 태그를 단 뒤 bare 저장소로 복제합니다.
+// file/HTTP 전송은 git 바이너리(git-upload-pack, git http-backend)를 사용하므로 없으면 건너뜁니다.
+func newBareRepo(tb testing.TB, files, size int) testRepo {
+        tb.Helper()
+        if _, err := exec.LookPath("git"); err != nil {
+                tb.Skip("git binary not available")
+        }
+
+        root := tb.TempDir()
+        workDir := filepath.Join(root, "work")
+        repo, err := git.PlainInit(workDir, false)
+        if err != nil {
+                tb.Fatalf("init: %v", err)
+        }
+        buf := make([]byte, size)
+        for i := 0; i < files; i++ {
+                _, _ = rand.Read(buf)
+                if err := os.WriteFile(filepath.Join(workDir, fmt.Sprintf("file-%03d.bin", i)), buf, 0o644); err != nil {
+                        tb.Fatalf("write: %v", err)
+                }
+        }
+        wt, err := repo.Worktree()
+        if err != nil {
+                tb.Fatalf("worktree: %v", err)
+        }
+        if err := wt.AddGlob("."); err != nil {
+                tb.Fatalf("add: %v", err)
+        }
+        sig := &object.Signature{Name: "bench", Email: "bench@example.com", When: time.Now()}
+        head, err := wt.Commit("initial", &git.CommitOptions{Author: sig})
+        if err != nil {
+                tb.Fatalf("commit: %v", err)
+        }
+        if _, err := repo.CreateTag(benchTag, head, &git.CreateTagOptions{Tagger: sig, Message: "release"}); err != nil {
+                tb.Fatalf("tag: %v", err)
+        }
+
+        bareDir := filepath.Join(root, "repo.git")
+        if _, err := git.PlainClone(bareDir, true, &git.CloneOptions{URL: workDir, Tags: git.AllTags}); err != nil {
+                tb.Fatalf("clone bare: %v", err)
+        }
+        return testRepo{Dir: bareDir, HeadSHA: head.String()}
+}
+
+// serveHTTP는 git http-backend 를 CGI 로 띄워 bare 저장소를 smart HTTP 로 제공합니다.
+func serveHTTP(tb testing.TB, repo testRepo) string {
+        tb.Helper()
+        gitBin, err := exec.LookPath("git")
+        if err != nil {
+                tb.Skip("git binary not available")
+        }
+        srv := httptest.NewServer(&cgi.Handler{
+                Path: gitBin,
+                Args: []string{"http-backend"},
+                Env: []string{
+                        "GIT_PROJECT_ROOT=" + filepath.Dir(repo.Dir),
+                        "GIT_HTTP_EXPORT_ALL=1",
+                },
+        })
+        tb.Cleanup(srv.Close)
+        return srv.URL + "/" + filepath.Base(repo.Dir)
+}
+
+// fetchRefShallow는 이전 구현처럼 메모리 저장소에 depth 1 fetch 를 하여 참조를 해석합니다. 비교용입니다.
+func fetchRefShallow(ctx context.Context, repoURL string, refName plumbing.ReferenceName) (ResolvedRef, error) {
+        repo, err := git.Init(memory.NewStorage(), nil)
+        if err != nil {
+                return ResolvedRef{}, err
+        }
+        if _, err := repo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{repoURL}}); err != nil {
+                return ResolvedRef{}, err
+        }
+        if err := repo.FetchContext(ctx, &git.FetchOptions{
+                RemoteName: "origin",
+                Depth:      1,
+                RefSpecs:   []config.RefSpec{config.RefSpec(fmt.Sprintf("+%s:%s", refName, refName))},
+                Tags:       git.NoTags,
+        }); err != nil && err != git.NoErrAlreadyUpToDate {
+                return ResolvedRef{}, err
+        }
+        ref, err := repo.Reference(refName, true)
+        if err != nil {
+                return ResolvedRef{}, err
+        }
+        return ResolvedRef{Name: refName.String(), SHA: ref.Hash().String()}, nil
+}
+
+func benchmarkResolve(b *testing.B, repoURL string, want string) {
+        refName := plumbing.NewBranchReferenceName("master")
+        ctx := context.Background()
+
+        b.Run("ls-remote", func(b *testing.B) {
+                for i := 0; i < b.N; i++ {
+                        refs, err := listRemoteRefs(ctx, repoURL, nil)
+                        if err != nil {
+                                b.Fatal(err)
+                        }
+                        got, err := findRef(refs, refName)
+                        if err != nil || got.SHA != want {
+                                b.Fatalf("got %v, %v", got, err)
+                        }
+                }
+        })
+        b.Run("shallow-fetch", func(b *testing.B) {
+                for i := 0; i < b.N; i++ {
+                        got, err := fetchRefShallow(ctx, repoURL, refName)
+                        if err != nil || got.SHA != want {
+                                b.Fatalf("got %v, %v", got, err)
+                        }
+                }
+        })
+}
+
+func BenchmarkResolve_File(b *testing.B) {
+        repo := newBareRepo(b, benchRepoFiles, benchRepoFileSize)
+        benchmarkResolve(b, "file://"+repo.Dir, repo.HeadSHA)
+}
+
+func BenchmarkResolve_HTTP(b *testing.B) {
+        repo := newBareRepo(b, benchRepoFiles, benchRepoFileSize)
+        benchmarkResolve(b, serveHTTP(b, repo), repo.HeadSHA)
+}
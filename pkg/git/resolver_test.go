@@ -2,16 +2,202 @@
 package git
 
 import (
+        "context"
+        "crypto/ed25519"
+        "crypto/rand"
+        "encoding/pem"
+        "fmt"
+        "sync"
+        "sync/atomic"
         "testing"
+        "time"
+
+        "github.com/go-git/go-git/v5/plumbing"
+        "github.com/go-git/go-git/v5/plumbing/transport"
+        "github.com/go-git/go-git/v5/plumbing/transport/http"
+        gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+        "golang.org/x/crypto/ssh"
+        corev1 "k8s.io/api/core/v1"
 )
 
+func newSSHKeyPEM(t *testing.T) ([]byte, ssh.PublicKey) {
+        pub, priv, err := ed25519.GenerateKey(rand.Reader)
+        if err != nil {
+                t.Fatalf("generate key: %v", err)
+        }
+        block, err := ssh.MarshalPrivateKey(priv, "")
+        if err != nil {
+                t.Fatalf("marshal key: %v", err)
+        }
+        sshPub, err := ssh.NewPublicKey(pub)
+        if err != nil {
+                t.Fatalf("public key: %v", err)
+        }
+        return pem.EncodeToMemory(block), sshPub
+}
+
 func TestGetGitAuthFromSecret(t *testing.T) {
-        // TODO: Secret 데이터로부터 인증 정보를 성공적으로 가져오는 케이스 테스트
-        // TODO: username 또는 password가 없는 Secret 데이터에 대한 에러 처리 테스트
+        // username/password → HTTP Basic
+        secret := &corev1.Secret{Data: map[string][]byte{UsernameField: []byte("user"), PasswordField: []byte("pass")}}
+        auth, err := GetGitAuthFromSecret(secret, "https://gitlab.com/group/app.git")
+        if err != nil {
+                t.Fatalf("unexpected error: %v", err)
+        }
+        if basic, ok := auth.(*http.BasicAuth); !ok || basic.Username != "user" || basic.Password != "pass" {
+                t.Errorf("expected basic auth user/pass, got %#v", auth)
+        }
+
+        // password 누락
+        secret = &corev1.Secret{Data: map[string][]byte{UsernameField: []byte("user")}}
+        if _, err := GetGitAuthFromSecret(secret, "https://gitlab.com/group/app.git"); err == nil {
+                t.Errorf("expected error for secret without password")
+        }
+
+        // kubernetes.io/ssh-auth + known_hosts → 엄격한 호스트 키 검사
+        keyPEM, pub := newSSHKeyPEM(t)
+        knownHosts := []byte("gitlab.com " + string(ssh.MarshalAuthorizedKey(pub)))
+        secret = &corev1.Secret{
+                Type: corev1.SecretTypeSSHAuth,
+                Data: map[string][]byte{SSHPrivateKeyField: keyPEM, KnownHostsField: knownHosts},
+        }
+        auth, err = GetGitAuthFromSecret(secret, "git@gitlab.com:group/app.git")
+        if err != nil {
+                t.Fatalf("unexpected error: %v", err)
+        }
+        pk, ok := auth.(*gitssh.PublicKeys)
+        if !ok || pk.User != "git" {
+                t.Fatalf("expected ssh public keys auth for user git, got %#v", auth)
+        }
+        addr := &fakeAddr{"gitlab.com:22"}
+        if err := pk.HostKeyCallback("gitlab.com:22", addr, pub); err != nil {
+                t.Errorf("expected known host key to be accepted: %v", err)
+        }
+        _, otherPub := newSSHKeyPEM(t)
+        if err := pk.HostKeyCallback("gitlab.com:22", addr, otherPub); err == nil {
+                t.Errorf("expected unknown host key to be rejected")
+        }
+
+        // tekton.dev/git-0 어노테이션이 다른 호스트를 가리키면 사용하지 않음
+        secret = &corev1.Secret{Data: map[string][]byte{UsernameField: []byte("user"), PasswordField: []byte("pass")}}
+        secret.Annotations = map[string]string{"tekton.dev/git-0": "https://github.com"}
+        auth, err = GetGitAuthFromSecret(secret, "https://gitlab.com/group/app.git")
+        if err != nil || auth != nil {
+                t.Errorf("expected nil auth for non-matching tekton annotation, got %#v, %v", auth, err)
+        }
+        secret.Annotations["tekton.dev/git-1"] = "https://gitlab.com"
+        if auth, _ := GetGitAuthFromSecret(secret, "https://gitlab.com/group/app.git"); auth == nil {
+                t.Errorf("expected auth for matching tekton annotation")
+        }
 }
 
+type fakeAddr struct{ s string }
+
+func (a *fakeAddr) Network() string { return "tcp" }
+func (a *fakeAddr) String() string  { return a.s }
+
 func TestResolver_ResolveGitSHA(t *testing.T) {
-        // TODO: Git SHA를 성공적으로 가져오는 케이스 테스트
-        // TODO: 캐시가 올바르게 동작하는지 테스트
-        // TODO: 인증 실패 시 에러 처리 테스트
+        repo := newBareRepo(t, 1, 16)
+        repoURL := "file://" + repo.Dir
+        r := NewResolver()
+        ctx := context.Background()
+
+        sha, err := r.ResolveGitSHA(ctx, repoURL, "master", nil)
+        if err != nil || sha != repo.HeadSHA {
+                t.Fatalf("expected %s, got %s, %v", repo.HeadSHA, sha, err)
+        }
+
+        // 주석 태그는 태그 객체가 아닌 커밋 SHA 로 해석되어야 함
+        tag, err := r.Resolve(ctx, repoURL, Ref{Tag: benchTag}, nil)
+        want := ResolvedRef{Name: "refs/tags/" + benchTag, SHA: repo.HeadSHA}
+        if err != nil || tag != want {
+                t.Errorf("expected %v, got %v, %v", want, tag, err)
+        }
+        if got, err := r.Resolve(ctx, repoURL, Ref{Semver: ">=1.0.0"}, nil); err != nil || got != want {
+                t.Errorf("expected semver range to resolve to %v, got %v, %v", want, got, err)
+        }
+
+        // 캐시된 값은 원격 조회 없이 반환
+        r.Cache.Add(repoURL, Ref{Branch: "master"}, nil, SHACacheEntry{SHA: "cached", RefName: "refs/heads/master", Timestamp: time.Now()})
+        if sha, _ := r.ResolveGitSHA(ctx, repoURL, "master", nil); sha != "cached" {
+                t.Errorf("expected cached SHA, got %s", sha)
+        }
+
+        if _, err := r.ResolveGitSHA(ctx, repoURL, "missing", nil); err == nil {
+                t.Errorf("expected error for missing branch")
+        }
+}
+
+func TestResolver_CoalescesConcurrentResolves(t *testing.T) {
+        var calls int32
+        release := make(chan struct{})
+        r := &Resolver{Cache: NewSHACache(10, time.Minute)}
+        r.listRefs = func(ctx context.Context, repoURL string, auth transport.AuthMethod) ([]*plumbing.Reference, error) {
+                atomic.AddInt32(&calls, 1)
+                <-release
+                return []*plumbing.Reference{plumbing.NewReferenceFromStrings("refs/heads/main", "1111111111111111111111111111111111111111")}, nil
+        }
+
+        const callers = 20
+        var wg sync.WaitGroup
+        results := make(chan string, callers)
+        for i := 0; i < callers; i++ {
+                wg.Add(1)
+                go func() {
+                        defer wg.Done()
+                        sha, err := r.ResolveGitSHA(context.Background(), "https://gitlab.example.com/group/app.git", "main", nil)
+                        if err != nil {
+                                t.Errorf("unexpected error: %v", err)
+                        }
+                        results <- sha
+                }()
+        }
+        // 모든 호출자가 진행 중인 조회에 합류할 시간을 줌
+        time.Sleep(50 * time.Millisecond)
+        close(release)
+        wg.Wait()
+        close(results)
+
+        if n := atomic.LoadInt32(&calls); n != 1 {
+                t.Errorf("expected 1 remote call, got %d", n)
+        }
+        for sha := range results {
+                if sha != "1111111111111111111111111111111111111111" {
+                        t.Errorf("unexpected sha %s", sha)
+                }
+        }
+}
+
+func TestResolver_LimitsConcurrencyPerHost(t *testing.T) {
+        var current, peak int32
+        r := &Resolver{MaxConcurrentPerHost: 2}
+        r.listRefs = func(ctx context.Context, repoURL string, auth transport.AuthMethod) ([]*plumbing.Reference, error) {
+                n := atomic.AddInt32(&current, 1)
+                for {
+                        p := atomic.LoadInt32(&peak)
+                        if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+                                break
+                        }
+                }
+                time.Sleep(20 * time.Millisecond)
+                atomic.AddInt32(&current, -1)
+                return []*plumbing.Reference{plumbing.NewReferenceFromStrings("refs/heads/main", "2222222222222222222222222222222222222222")}, nil
+        }
+
+        // 서로 다른 저장소라 합쳐지지 않지만 같은 호스트이므로 동시 실행 수가 제한되어야 함
+        var wg sync.WaitGroup
+        for i := 0; i < 6; i++ {
+                wg.Add(1)
+                go func(i int) {
+                        defer wg.Done()
+                        url := fmt.Sprintf("https://gitlab.example.com/group/app%d", i)
+                        if _, err := r.ResolveGitSHA(context.Background(), url, "main", nil); err != nil {
+                                t.Errorf("unexpected error: %v", err)
+                        }
+                }(i)
+        }
+        wg.Wait()
+
+        if p := atomic.LoadInt32(&peak); p > 2 {
+                t.Errorf("expected at most 2 concurrent remote calls, got %d", p)
+        }
 }
@@ -0,0 +1,188 @@
+// File: pkg/gitwebhook/payload.go
+package gitwebhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Provider identifies the Git server that sent a webhook.
+type Provider string
+
+const (
+	ProviderGitLab Provider = "gitlab"
+	ProviderGitHub Provider = "github"
+	ProviderGitea  Provider = "gitea"
+)
+
+// Provider specific headers.
+const (
+	gitlabEventHeader     = "X-Gitlab-Event"
+	gitlabTokenHeader     = "X-Gitlab-Token"
+	githubEventHeader     = "X-GitHub-Event"
+	githubSignatureHeader = "X-Hub-Signature-256"
+	giteaEventHeader      = "X-Gitea-Event"
+	giteaSignatureHeader  = "X-Gitea-Signature"
+
+	gitlabPushEvent    = "Push Hook"
+	gitlabTagPushEvent = "Tag Push Hook"
+	githubPushEvent    = "push"
+	giteaPushEvent     = "push"
+
+	branchRefPrefix = "refs/heads/"
+	tagRefPrefix    = "refs/tags/"
+)
+
+// PushEvent is the provider independent part of a push webhook.
+type PushEvent struct {
+	Provider Provider
+	// RepoURLs holds every clone URL advertised for the repository (http and ssh).
+	RepoURLs []string
+	// Branch is the pushed branch, empty for tag pushes.
+	Branch string
+	// Tag is the pushed tag, empty for branch pushes.
+	Tag string
+	// After is the commit SHA the ref now points to.
+	After string
+}
+
+// DetectProvider returns the provider based on its event header, or "" if none is present.
+// Gitea also sends X-GitHub-Event, so it is checked first.
+func DetectProvider(h http.Header) Provider {
+	switch {
+	case h.Get(giteaEventHeader) != "":
+		return ProviderGitea
+	case h.Get(gitlabEventHeader) != "":
+		return ProviderGitLab
+	case h.Get(githubEventHeader) != "":
+		return ProviderGitHub
+	}
+	return ""
+}
+
+// IsPushEvent reports whether the request headers announce a push event.
+func IsPushEvent(p Provider, h http.Header) bool {
+	switch p {
+	case ProviderGitLab:
+		e := h.Get(gitlabEventHeader)
+		return e == gitlabPushEvent || e == gitlabTagPushEvent
+	case ProviderGitHub:
+		return h.Get(githubEventHeader) == githubPushEvent
+	case ProviderGitea:
+		return h.Get(giteaEventHeader) == giteaPushEvent
+	}
+	return false
+}
+
+// VerifySignature checks the shared secret: GitLab sends it verbatim as a token,
+// GitHub and Gitea send an HMAC-SHA256 of the body.
+func VerifySignature(p Provider, h http.Header, body, secret []byte) error {
+	if len(secret) == 0 {
+		return fmt.Errorf("webhook secret is not configured")
+	}
+	switch p {
+	case ProviderGitLab:
+		if subtle.ConstantTimeCompare([]byte(h.Get(gitlabTokenHeader)), secret) != 1 {
+			return fmt.Errorf("invalid %s", gitlabTokenHeader)
+		}
+		return nil
+	case ProviderGitHub:
+		sig, ok := strings.CutPrefix(h.Get(githubSignatureHeader), "sha256=")
+		if !ok {
+			return fmt.Errorf("missing %s", githubSignatureHeader)
+		}
+		return verifyHMAC(sig, body, secret)
+	case ProviderGitea:
+		return verifyHMAC(h.Get(giteaSignatureHeader), body, secret)
+	}
+	return fmt.Errorf("unknown provider %q", p)
+}
+
+func verifyHMAC(sigHex string, body, secret []byte) error {
+	sig, err := hex.DecodeString(sigHex)
+	if err != nil || len(sig) == 0 {
+		return fmt.Errorf("malformed signature")
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	if !hmac.Equal(sig, mac.Sum(nil)) {
+		return fmt.Errorf("signature mismatch")
+	}
+	return nil
+}
+
+type gitlabPush struct {
+	Ref         string `json:"ref"`
+	CheckoutSHA string `json:"checkout_sha"`
+	After       string `json:"after"`
+	Project     struct {
+		GitHTTPURL string `json:"git_http_url"`
+		GitSSHURL  string `json:"git_ssh_url"`
+		WebURL     string `json:"web_url"`
+	} `json:"project"`
+}
+
+// githubPush also matches the Gitea push payload.
+type githubPush struct {
+	Ref        string `json:"ref"`
+	After      string `json:"after"`
+	Repository struct {
+		CloneURL string `json:"clone_url"`
+		SSHURL   string `json:"ssh_url"`
+		HTMLURL  string `json:"html_url"`
+	} `json:"repository"`
+}
+
+// ParsePushEvent decodes a push payload of the given provider.
+func ParsePushEvent(p Provider, body []byte) (*PushEvent, error) {
+	ev := &PushEvent{Provider: p}
+	var ref string
+	switch p {
+	case ProviderGitLab:
+		var pl gitlabPush
+		if err := json.Unmarshal(body, &pl); err != nil {
+			return nil, fmt.Errorf("decode gitlab push: %w", err)
+		}
+		ref = pl.Ref
+		ev.After = pl.CheckoutSHA
+		if ev.After == "" {
+			ev.After = pl.After
+		}
+		ev.RepoURLs = nonEmpty(pl.Project.GitHTTPURL, pl.Project.GitSSHURL, pl.Project.WebURL)
+	case ProviderGitHub, ProviderGitea:
+		var pl githubPush
+		if err := json.Unmarshal(body, &pl); err != nil {
+			return nil, fmt.Errorf("decode %s push: %w", p, err)
+		}
+		ref = pl.Ref
+		ev.After = pl.After
+		ev.RepoURLs = nonEmpty(pl.Repository.CloneURL, pl.Repository.SSHURL, pl.Repository.HTMLURL)
+	default:
+		return nil, fmt.Errorf("unknown provider %q", p)
+	}
+	if len(ev.RepoURLs) == 0 {
+		return nil, fmt.Errorf("push payload has no repository URL")
+	}
+	if branch, ok := strings.CutPrefix(ref, branchRefPrefix); ok {
+		ev.Branch = branch
+	} else if tag, ok := strings.CutPrefix(ref, tagRefPrefix); ok {
+		ev.Tag = tag
+	}
+	return ev, nil
+}
+
+func nonEmpty(values ...string) []string {
+	var out []string
+	for _, v := range values {
+		if v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,176 @@
+// File: pkg/gitwebhook/receiver.go
+package gitwebhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	workloadv1alpha1 "tekton-controller/api/v1alpha1"
+	"tekton-controller/pkg/git"
+	"tekton-controller/pkg/util"
+)
+
+const (
+	// Path is the URL path push webhooks are posted to.
+	Path = "/hooks/git"
+
+	maxPayloadBytes = 10 << 20
+)
+
+// Receiver accepts push webhooks from GitLab, GitHub and Gitea, invalidates the
+// resolver cache for the pushed branch or tag and enqueues every matching Workload.
+type Receiver struct {
+	Client   client.Reader
+	Resolver *git.Resolver
+	// Secret is the shared webhook secret (GitLab token / GitHub and Gitea HMAC key).
+	Secret []byte
+	// Events receives a GenericEvent for every Workload that must be reconciled.
+	Events chan<- event.GenericEvent
+	// BindAddress is the address the HTTP server listens on.
+	BindAddress string
+}
+
+// Start runs the HTTP server until ctx is cancelled. It implements manager.Runnable.
+// The receiver runs only on the elected leader: Events is drained by the leader's
+// WorkloadReconciler and Invalidate clears the leader's SHA cache.
+func (rc *Receiver) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("git-webhook")
+	mux := http.NewServeMux()
+	mux.Handle(Path, rc)
+	srv := &http.Server{
+		Addr:              rc.BindAddress,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(_ net.Listener) context.Context { return log.IntoContext(context.Background(), logger) },
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		logger.Info("Starting Git webhook receiver", "address", rc.BindAddress, "path", Path)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+		}
+		close(errCh)
+	}()
+
+	select {
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return srv.Shutdown(shutdownCtx)
+	case err := <-errCh:
+		return err
+	}
+}
+
+// ServeHTTP handles a single webhook delivery.
+func (rc *Receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	logger := log.FromContext(ctx)
+
+	if req.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	provider := DetectProvider(req.Header)
+	if provider == "" {
+		http.Error(w, "unknown webhook provider", http.StatusBadRequest)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(req.Body, maxPayloadBytes))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+	if err := VerifySignature(provider, req.Header, body, rc.Secret); err != nil {
+		logger.Info("Rejected webhook with invalid signature", "provider", provider, "reason", err.Error())
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+	if !IsPushEvent(provider, req.Header) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	ev, err := ParsePushEvent(provider, body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if ev.Branch == "" && ev.Tag == "" {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	matched, err := rc.enqueueMatching(ctx, ev)
+	if err != nil {
+		logger.Error(err, "Failed to enqueue Workloads for push", "provider", provider, "branch", ev.Branch, "tag", ev.Tag)
+		http.Error(w, "failed to enqueue workloads", http.StatusInternalServerError)
+		return
+	}
+	logger.Info("Handled push webhook", "provider", provider, "repo", ev.RepoURLs[0],
+		"branch", ev.Branch, "tag", ev.Tag, "after", ev.After, "workloads", matched)
+	w.WriteHeader(http.StatusAccepted)
+	_, _ = fmt.Fprintf(w, "%d workload(s) enqueued\n", matched)
+}
+
+// enqueueMatching finds the Workloads building ev's repository and ref,
+// invalidates their cached SHA and sends them to the Events channel.
+func (rc *Receiver) enqueueMatching(ctx context.Context, ev *PushEvent) (int, error) {
+	pushed := make(map[string]struct{}, len(ev.RepoURLs))
+	for _, u := range ev.RepoURLs {
+		pushed[util.NormalizeRepoURL(u)] = struct{}{}
+	}
+
+	var list workloadv1alpha1.WorkloadList
+	if err := rc.Client.List(ctx, &list); err != nil {
+		return 0, fmt.Errorf("list workloads: %w", err)
+	}
+
+	matched := 0
+	for i := range list.Items {
+		wl := &list.Items[i]
+		if wl.Spec.Source == nil || wl.Spec.Source.Git == nil {
+			continue
+		}
+		src := wl.Spec.Source.Git
+		if !refMatchesPush(src.Ref, ev) {
+			continue
+		}
+		if _, ok := pushed[util.NormalizeRepoURL(src.URL)]; !ok {
+			continue
+		}
+		rc.Resolver.Invalidate(src.URL, git.RefFromSpec(src.Ref))
+		select {
+		case rc.Events <- event.GenericEvent{Object: wl}:
+			matched++
+		case <-ctx.Done():
+			return matched, ctx.Err()
+		}
+	}
+	return matched, nil
+}
+
+// refMatchesPush reports whether a push affects the given ref. Branch refs match
+// the pushed branch; tag and semver refs match any tag push since a new tag may
+// satisfy the range. Commit refs never move.
+func refMatchesPush(ref workloadv1alpha1.GitRef, ev *PushEvent) bool {
+	switch {
+	case ref.Branch != "":
+		return ref.Branch == ev.Branch
+	case ref.Tag != "":
+		return ref.Tag == ev.Tag
+	case ref.Semver != "":
+		return ev.Tag != ""
+	}
+	return false
+}
@@ -0,0 +1,181 @@
+// File: pkg/gitwebhook/receiver_test.go
+package gitwebhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	workloadv1alpha1 "tekton-controller/api/v1alpha1"
+	"tekton-controller/pkg/git"
+)
+
+const testSecret = "s3cr3t"
+
+func newWorkload(ns, name, url, branch string) *workloadv1alpha1.Workload {
+	wl := &workloadv1alpha1.Workload{}
+	wl.SetNamespace(ns)
+	wl.SetName(name)
+	wl.Spec.Source = &workloadv1alpha1.Source{Git: &workloadv1alpha1.GitSource{
+		URL: url,
+		Ref: workloadv1alpha1.GitRef{Branch: branch},
+	}}
+	return wl
+}
+
+func newReceiver(t *testing.T, events chan event.GenericEvent, extra ...client.Object) *Receiver {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, workloadv1alpha1.AddToScheme(scheme))
+	objs := append([]client.Object{
+		newWorkload("team-a", "app", "git@gitlab.example.com:group/app.git", "main"),
+		newWorkload("team-b", "app", "https://gitlab.example.com/group/app", "develop"),
+		newWorkload("team-c", "other", "https://gitlab.example.com/group/other.git", "main"),
+	}, extra...)
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	return &Receiver{
+		Client:   cli,
+		Resolver: git.NewResolver(),
+		Secret:   []byte(testSecret),
+		Events:   events,
+	}
+}
+
+func TestReceiver_GitLabPushEnqueuesMatchingWorkloads(t *testing.T) {
+	events := make(chan event.GenericEvent, 10)
+	rc := newReceiver(t, events)
+	mainRef := git.Ref{Branch: "main"}
+	rc.Resolver.Cache.Add("git@gitlab.example.com:group/app.git", mainRef, nil, git.SHACacheEntry{SHA: "old", Timestamp: time.Now()})
+
+	body := []byte(`{"ref":"refs/heads/main","checkout_sha":"abc123",
+		"project":{"git_http_url":"https://gitlab.example.com/group/app.git","git_ssh_url":"git@gitlab.example.com:group/app.git"}}`)
+	req := httptest.NewRequest(http.MethodPost, Path, bytes.NewReader(body))
+	req.Header.Set(gitlabEventHeader, gitlabPushEvent)
+	req.Header.Set(gitlabTokenHeader, testSecret)
+	rec := httptest.NewRecorder()
+	rc.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Len(t, events, 1)
+	ev := <-events
+	assert.Equal(t, "team-a", ev.Object.GetNamespace())
+	_, cached := rc.Resolver.Cache.Get("git@gitlab.example.com:group/app.git", mainRef, nil)
+	assert.False(t, cached, "cache entry should be invalidated")
+}
+
+func TestReceiver_GitLabTagPushEnqueuesTagWorkloads(t *testing.T) {
+	url := "https://gitlab.example.com/group/app.git"
+	tagged := newWorkload("team-d", "tagged", url, "")
+	tagged.Spec.Source.Git.Ref = workloadv1alpha1.GitRef{Tag: "v1.2.0"}
+	ranged := newWorkload("team-e", "ranged", url, "")
+	ranged.Spec.Source.Git.Ref = workloadv1alpha1.GitRef{Semver: ">=1.0.0 <2.0.0"}
+	other := newWorkload("team-f", "other-tag", url, "")
+	other.Spec.Source.Git.Ref = workloadv1alpha1.GitRef{Tag: "v0.9.0"}
+	events := make(chan event.GenericEvent, 10)
+	rc := newReceiver(t, events, tagged, ranged, other)
+
+	body := []byte(`{"ref":"refs/tags/v1.2.0","checkout_sha":"abc123",
+		"project":{"git_http_url":"https://gitlab.example.com/group/app.git","git_ssh_url":"git@gitlab.example.com:group/app.git"}}`)
+	req := httptest.NewRequest(http.MethodPost, Path, bytes.NewReader(body))
+	req.Header.Set(gitlabEventHeader, gitlabTagPushEvent)
+	req.Header.Set(gitlabTokenHeader, testSecret)
+	rec := httptest.NewRecorder()
+	rc.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	var got []string
+	for len(events) > 0 {
+		ev := <-events
+		got = append(got, ev.Object.GetNamespace())
+	}
+	assert.ElementsMatch(t, []string{"team-d", "team-e"}, got)
+}
+
+func TestReceiver_GitHubSignature(t *testing.T) {
+	events := make(chan event.GenericEvent, 10)
+	rc := newReceiver(t, events)
+
+	body := []byte(`{"ref":"refs/heads/develop","after":"def456",
+		"repository":{"clone_url":"https://gitlab.example.com/group/app.git"}}`)
+	mac := hmac.New(sha256.New, []byte(testSecret))
+	mac.Write(body)
+
+	req := httptest.NewRequest(http.MethodPost, Path, bytes.NewReader(body))
+	req.Header.Set(githubEventHeader, githubPushEvent)
+	req.Header.Set(githubSignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	rec := httptest.NewRecorder()
+	rc.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Len(t, events, 1)
+
+	req = httptest.NewRequest(http.MethodPost, Path, bytes.NewReader(body))
+	req.Header.Set(githubEventHeader, githubPushEvent)
+	req.Header.Set(githubSignatureHeader, "sha256=00")
+	rec = httptest.NewRecorder()
+	rc.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestReceiver_GitLabWrongToken(t *testing.T) {
+	events := make(chan event.GenericEvent, 10)
+	rc := newReceiver(t, events)
+
+	req := httptest.NewRequest(http.MethodPost, Path, bytes.NewReader([]byte(`{}`)))
+	req.Header.Set(gitlabEventHeader, gitlabPushEvent)
+	req.Header.Set(gitlabTokenHeader, "wrong")
+	rec := httptest.NewRecorder()
+	rc.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Len(t, events, 0)
+}
+
+func TestReceiver_DeliveryReachesReconcileQueue(t *testing.T) {
+	// Same wiring as WorkloadReconciler.SetupWithManager: the receiver's Events
+	// channel feeds a source.Channel that enqueues reconcile requests.
+	events := make(chan event.GenericEvent, 10)
+	rc := newReceiver(t, events)
+	queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+	defer queue.ShutDown()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	src := source.Channel(events, &handler.EnqueueRequestForObject{})
+	assert.NoError(t, src.Start(ctx, queue))
+
+	body := []byte(`{"ref":"refs/heads/main","checkout_sha":"abc123",
+		"project":{"git_http_url":"https://gitlab.example.com/group/app.git","git_ssh_url":"git@gitlab.example.com:group/app.git"}}`)
+	req := httptest.NewRequest(http.MethodPost, Path, bytes.NewReader(body))
+	req.Header.Set(gitlabEventHeader, gitlabPushEvent)
+	req.Header.Set(gitlabTokenHeader, testSecret)
+	rec := httptest.NewRecorder()
+	rc.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+
+	assert.Eventually(t, func() bool { return queue.Len() == 1 }, time.Second, 10*time.Millisecond)
+	item, _ := queue.Get()
+	assert.Equal(t, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "team-a", Name: "app"}}, item)
+}
+
+func TestReceiver_RequiresLeaderElection(t *testing.T) {
+	// The manager only runs runnables on the leader unless they opt out.
+	var r manager.Runnable = &Receiver{}
+	_, optsOut := r.(manager.LeaderElectionRunnable)
+	assert.False(t, optsOut)
+}
@@ -0,0 +1,81 @@
+// File: pkg/health/checks.go
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+
+	"tekton-controller/pkg/git"
+)
+
+const (
+	cacheSyncTimeout = 2 * time.Second
+	// DefaultGitCheckInterval is how long a Git self-test result is reused
+	// so that probes do not hit the Git server every few seconds.
+	DefaultGitCheckInterval = time.Minute
+)
+
+// CacheSyncer is implemented by the manager's cache.
+type CacheSyncer interface {
+	WaitForCacheSync(ctx context.Context) bool
+}
+
+// CacheSynced fails until every informer started by the manager has synced.
+func CacheSynced(c CacheSyncer) healthz.Checker {
+	return func(req *http.Request) error {
+		ctx, cancel := context.WithTimeout(req.Context(), cacheSyncTimeout)
+		defer cancel()
+		if !c.WaitForCacheSync(ctx) {
+			return fmt.Errorf("informer caches have not synced")
+		}
+		return nil
+	}
+}
+
+// ResourceDiscoverable fails until the API server serves resource in gv,
+// i.e. the CRD defining it is installed and established.
+func ResourceDiscoverable(dc discovery.DiscoveryInterface, gv schema.GroupVersion, resource string) healthz.Checker {
+	return func(_ *http.Request) error {
+		list, err := dc.ServerResourcesForGroupVersion(gv.String())
+		if err != nil {
+			return fmt.Errorf("discover %s: %w", gv, err)
+		}
+		for _, r := range list.APIResources {
+			if r.Name == resource {
+				return nil
+			}
+		}
+		return fmt.Errorf("resource %s not served by %s", resource, gv)
+	}
+}
+
+// GitReachable lists the refs of repoURL and fails if the remote cannot be
+// reached. The result is cached for interval.
+func GitReachable(repoURL string, auth transport.AuthMethod, interval time.Duration) healthz.Checker {
+	if interval <= 0 {
+		interval = DefaultGitCheckInterval
+	}
+	var (
+		mu      sync.Mutex
+		checked time.Time
+		lastErr error
+	)
+	return func(req *http.Request) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if !checked.IsZero() && time.Since(checked) < interval {
+			return lastErr
+		}
+		lastErr = git.CheckConnectivity(req.Context(), repoURL, auth)
+		checked = time.Now()
+		return lastErr
+	}
+}
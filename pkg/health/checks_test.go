@@ -0,0 +1,49 @@
+// File: pkg/health/checks_test.go
+package health
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+type fakeCache struct{ synced bool }
+
+func (c fakeCache) WaitForCacheSync(_ context.Context) bool { return c.synced }
+
+func TestCacheSynced(t *testing.T) {
+	req := httptest.NewRequest("GET", "/readyz", nil)
+	assert.Error(t, CacheSynced(fakeCache{synced: false})(req))
+	assert.NoError(t, CacheSynced(fakeCache{synced: true})(req))
+}
+
+func TestResourceDiscoverable(t *testing.T) {
+	dc := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{}}
+	dc.Resources = []*metav1.APIResourceList{{
+		GroupVersion: "tekton.platform/v1alpha1",
+		APIResources: []metav1.APIResource{{Name: "workloads", Kind: "Workload"}},
+	}}
+	req := httptest.NewRequest("GET", "/readyz", nil)
+
+	check := ResourceDiscoverable(dc, schema.GroupVersion{Group: "tekton.platform", Version: "v1alpha1"}, "workloads")
+	assert.NoError(t, check(req))
+
+	check = ResourceDiscoverable(dc, schema.GroupVersion{Group: "tekton.dev", Version: "v1"}, "pipelines")
+	assert.Error(t, check(req), "missing group version must fail")
+}
+
+func TestGitReachable_CachesResult(t *testing.T) {
+	// 접근할 수 없는 저장소: 첫 호출은 실패하고, interval 동안은 같은 결과를 재사용
+	check := GitReachable("file:///nonexistent/repo.git", nil, time.Hour)
+	req := httptest.NewRequest("GET", "/readyz", nil)
+	first := check(req)
+	assert.Error(t, first)
+	assert.Equal(t, first, check(req))
+}
@@ -3,21 +3,25 @@ package pipeline
 
 
 import (
+    "bytes"
     "context"
+    "crypto/sha256"
+    "encoding/hex"
     "encoding/json"
     "fmt"
     "sort"
-    "time"
+    "strconv"
+    "strings"
 
     apierrors "k8s.io/apimachinery/pkg/api/errors"
     metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-    "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-    "k8s.io/apimachinery/pkg/runtime/schema"
     corev1 "k8s.io/api/core/v1"
-    pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+    "k8s.io/apimachinery/pkg/api/resource"
+    pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
     "sigs.k8s.io/controller-runtime/pkg/client"
     "sigs.k8s.io/controller-runtime/pkg/log"
 
+    workloadv1alpha1 "tekton-controller/api/v1alpha1"
     "tekton-controller/pkg/util"
 )
 
@@ -26,10 +30,22 @@ const (
     WorkloadNameParam         = "workloadname"
     WorkloadKind              = "Workload"
     DefaultServiceAccountName = "pipeline"
+
+    // BuildServiceBindingsParam is the Workload param holding a JSON list of ServiceBinding.
+    BuildServiceBindingsParam = "buildServiceBindings"
+    // ImageRepoAddressParam and ImageRepoPathParam select where built images are pushed.
+    ImageRepoAddressParam = "image-repo-address"
+    ImageRepoPathParam    = "image-repo-path"
+    // BuildEnvParam and AppEnvParam are array params of NAME=VALUE entries taken from
+    // spec.build.env (image build) and spec.env (deployed application).
+    BuildEnvParam = "build-env"
+    AppEnvParam   = "app-env"
 )
 
-var (
-    WorkloadApiGroupVersion = schema.GroupVersion{Group: "tekton.platform", Version: "v1alpha1"}
+// Labels recorded on every PipelineRun so a build can be matched back to its inputs.
+const (
+    CommitSHALabel = "tekton.platform/commit-sha"
+    BuildHashLabel = "tekton.platform/build-hash"
 )
 
 // ServiceBinding represents a binding object in the CR spec.
@@ -39,34 +55,19 @@ type ServiceBinding struct {
     Provider string `json:"provider"`
 }
 
-// ExtractServiceBindings reads rawParams[name==key] and returns a slice of ServiceBinding.
-func ExtractServiceBindings(rawParams []interface{}, key string) ([]ServiceBinding, error) {
+// ExtractServiceBindings decodes the value of the param named key into a slice of ServiceBinding.
+// Entries without a name are skipped.
+func ExtractServiceBindings(specParams []workloadv1alpha1.Param, key string) ([]ServiceBinding, error) {
     var result []ServiceBinding
-    for _, p := range rawParams {
-        pm, ok := p.(map[string]interface{})
-        if !ok {
+    for _, p := range specParams {
+        if p.Name != key || len(p.Value.Raw) == 0 {
             continue
         }
-        nameVal, ok := pm["name"].(string)
-        if !ok || nameVal != key {
-            continue
+        var arr []ServiceBinding
+        if err := json.Unmarshal(p.Value.Raw, &arr); err != nil {
+            return nil, fmt.Errorf("decode param %q: %w", key, err)
         }
-        arr, _ := pm["value"].([]interface{})
-        for _, item := range arr {
-            m, ok := item.(map[string]interface{})
-            if !ok {
-                continue
-            }
-            sb := ServiceBinding{}
-            if v, ok := m["name"].(string); ok {
-                sb.Name = v
-            }
-            if v, ok := m["type"].(string); ok {
-                sb.Type = v
-            }
-            if v, ok := m["provider"].(string); ok {
-                sb.Provider = v
-            }
+        for _, sb := range arr {
             if sb.Name != "" {
                 result = append(result, sb)
             }
@@ -87,10 +88,10 @@ func BuildServiceBindingsJSON(sb []ServiceBinding) (string, error) {
 // AppendServiceBindingWorkspaces adds each binding.Name as a Secret workspace,
 // but only if that workspace was declared in the pipeline spec.
 func AppendServiceBindingWorkspaces(ctx context.Context, cl client.Client, ns string,
-    wsDecls []pipelinev1beta1.PipelineWorkspaceDeclaration,
+    wsDecls []pipelinev1.PipelineWorkspaceDeclaration,
     currentPVC string,
     sb []ServiceBinding,
-) ([]pipelinev1beta1.WorkspaceBinding, error) {
+) ([]pipelinev1.WorkspaceBinding, error) {
     // base PVC + secret bindings
     wsBindings, err := BuildWorkspaceBindings(ctx, cl, ns, wsDecls, currentPVC)
     if err != nil {
@@ -111,7 +112,7 @@ func AppendServiceBindingWorkspaces(ctx context.Context, cl client.Client, ns st
             continue
         }
         logger.V(1).Info("Adding service-binding workspace", "secret", bind.Name)
-        wsBindings = append(wsBindings, pipelinev1beta1.WorkspaceBinding{
+        wsBindings = append(wsBindings, pipelinev1.WorkspaceBinding{
             Name:   bind.Name,
             Secret: &corev1.SecretVolumeSource{SecretName: bind.Name},
         })
@@ -119,58 +120,271 @@ func AppendServiceBindingWorkspaces(ctx context.Context, cl client.Client, ns st
     return wsBindings, nil
 }
 
-// ParamMapFromSpec converts a []interface{} spec params into map[name]value.
-func ParamMapFromSpec(specParams []interface{}) map[string]string {
-    m := make(map[string]string, len(specParams))
+// ParamMapFromSpec converts the Workload spec params into map[name]value, keeping
+// strings, arrays and objects. Numbers and booleans become strings; arrays and objects
+// holding nested values are passed as a JSON string.
+func ParamMapFromSpec(specParams []workloadv1alpha1.Param) map[string]pipelinev1.ParamValue {
+    m := make(map[string]pipelinev1.ParamValue, len(specParams))
     for _, p := range specParams {
-        if pm, ok := p.(map[string]interface{}); ok {
-            nameStr, nameOk := pm["name"].(string)
-            valueStr, valueOk := pm["value"].(string)
-            if nameOk && valueOk {
-                m[nameStr] = valueStr
-            } else {
-                m[fmt.Sprintf("%v", pm["name"])] = fmt.Sprintf("%v", pm["value"])
+        m[p.Name] = paramValueFromJSON(p.Value.Raw)
+    }
+    return m
+}
+
+func paramValueFromJSON(raw []byte) pipelinev1.ParamValue {
+    if len(raw) == 0 {
+        return *pipelinev1.NewStructuredValues("")
+    }
+    dec := json.NewDecoder(bytes.NewReader(raw))
+    dec.UseNumber()
+    var v interface{}
+    if err := dec.Decode(&v); err != nil {
+        return *pipelinev1.NewStructuredValues(string(raw))
+    }
+    switch t := v.(type) {
+    case []interface{}:
+        arr := make([]string, 0, len(t))
+        for _, e := range t {
+            str, ok := scalarString(e)
+            if !ok {
+                return jsonStringValue(raw)
+            }
+            arr = append(arr, str)
+        }
+        return pipelinev1.ParamValue{Type: pipelinev1.ParamTypeArray, ArrayVal: arr}
+    case map[string]interface{}:
+        obj := make(map[string]string, len(t))
+        for k, e := range t {
+            str, ok := scalarString(e)
+            if !ok {
+                return jsonStringValue(raw)
             }
+            obj[k] = str
         }
+        return pipelinev1.ParamValue{Type: pipelinev1.ParamTypeObject, ObjectVal: obj}
+    default:
+        str, _ := scalarString(v)
+        return *pipelinev1.NewStructuredValues(str)
     }
-    return m
 }
 
-// BuildPipelineRunParams turns a map of params into a sorted []pipelinev1beta1.Param.
-func BuildPipelineRunParams(paramsMap map[string]string) []pipelinev1beta1.Param {
+// scalarString formats a decoded JSON scalar; ok is false for arrays and objects.
+func scalarString(v interface{}) (s string, ok bool) {
+    switch t := v.(type) {
+    case string:
+        return t, true
+    case json.Number:
+        return t.String(), true
+    case bool:
+        return strconv.FormatBool(t), true
+    case nil:
+        return "", true
+    }
+    return "", false
+}
+
+// jsonStringValue passes raw JSON as a compact string value.
+func jsonStringValue(raw []byte) pipelinev1.ParamValue {
+    var buf bytes.Buffer
+    if err := json.Compact(&buf, raw); err != nil {
+        return *pipelinev1.NewStructuredValues(string(raw))
+    }
+    return *pipelinev1.NewStructuredValues(buf.String())
+}
+
+// BuildPipelineRunParams turns a map of params into a sorted []pipelinev1.Param.
+func BuildPipelineRunParams(paramsMap map[string]pipelinev1.ParamValue) []pipelinev1.Param {
     var keys []string
     for k := range paramsMap {
         keys = append(keys, k)
     }
     sort.Strings(keys)
-    var params []pipelinev1beta1.Param
+    var params []pipelinev1.Param
     for _, k := range keys {
-        params = append(params, pipelinev1beta1.Param{
-            Name:  k,
-            Value: *pipelinev1beta1.NewArrayOrString(paramsMap[k]),
-        })
+        params = append(params, pipelinev1.Param{Name: k, Value: paramsMap[k]})
+    }
+    return params
+}
+
+// CheckParamTypes checks params against the types declared in specs. Array and object
+// values of params declared as strings are passed as JSON; other mismatches are errors.
+// Params that specs does not declare are returned unchanged.
+func CheckParamTypes(specs pipelinev1.ParamSpecs, params []pipelinev1.Param) ([]pipelinev1.Param, error) {
+    declared := make(map[string]pipelinev1.ParamType, len(specs))
+    for _, ps := range specs {
+        declared[ps.Name] = ps.Type
+    }
+    out := make([]pipelinev1.Param, 0, len(params))
+    var mismatches []string
+    for _, p := range params {
+        want, ok := declared[p.Name]
+        if want == "" {
+            want = pipelinev1.ParamTypeString
+        }
+        switch {
+        case !ok || p.Value.Type == want:
+        case want == pipelinev1.ParamTypeString:
+            b, err := json.Marshal(p.Value)
+            if err != nil {
+                return nil, fmt.Errorf("marshal param %q: %w", p.Name, err)
+            }
+            p.Value = *pipelinev1.NewStructuredValues(string(b))
+        default:
+            mismatches = append(mismatches, fmt.Sprintf("%s is declared as %s but given %s", p.Name, want, p.Value.Type))
+        }
+        out = append(out, p)
+    }
+    if len(mismatches) > 0 {
+        return nil, fmt.Errorf("param %s", strings.Join(mismatches, ", "))
+    }
+    return out, nil
+}
+
+// ReconcileParams fits params to the params specs declares. A param the Pipeline does not
+// declare is renamed to a declared alias from aliases (which apply both ways), or else
+// dropped and returned in ignored. Declared defaults are filled in, and declared params
+// without a default that are still missing are an error. The result is sorted by name.
+func ReconcileParams(specs pipelinev1.ParamSpecs, params []pipelinev1.Param, aliases map[string]string) (out []pipelinev1.Param, ignored []string, err error) {
+    declared := make(map[string]bool, len(specs))
+    for _, ps := range specs {
+        declared[ps.Name] = true
+    }
+    alternatives := make(map[string][]string, 2*len(aliases))
+    for name, alias := range aliases {
+        alternatives[name] = append(alternatives[name], alias)
+        alternatives[alias] = append(alternatives[alias], name)
+    }
+    supplied := make(map[string]bool, len(params))
+    for _, p := range params {
+        supplied[p.Name] = true
+    }
+
+    set := make(map[string]bool, len(params))
+    for _, p := range params {
+        name := p.Name
+        if !declared[name] {
+            name = ""
+            alts := alternatives[p.Name]
+            sort.Strings(alts)
+            for _, alt := range alts {
+                if declared[alt] && !supplied[alt] {
+                    name = alt
+                    break
+                }
+            }
+        }
+        if name == "" || set[name] {
+            ignored = append(ignored, p.Name)
+            continue
+        }
+        set[name] = true
+        p.Name = name
+        out = append(out, p)
+    }
+
+    var missing []string
+    for _, ps := range specs {
+        switch {
+        case set[ps.Name]:
+        case ps.Default != nil:
+            out = append(out, pipelinev1.Param{Name: ps.Name, Value: *ps.Default.DeepCopy()})
+        default:
+            missing = append(missing, ps.Name)
+        }
+    }
+    sort.Strings(ignored)
+    if len(missing) > 0 {
+        sort.Strings(missing)
+        return nil, ignored, fmt.Errorf("params %s are required but not set", strings.Join(missing, ", "))
+    }
+    sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+    return out, ignored, nil
+}
+
+// AddEnvParam adds env to params as the array param name of NAME=VALUE entries,
+// keeping params sorted. Nothing is added when env is empty or name is already set.
+func AddEnvParam(params []pipelinev1.Param, name string, env []workloadv1alpha1.EnvVar) []pipelinev1.Param {
+    if len(env) == 0 {
+        return params
+    }
+    for _, p := range params {
+        if p.Name == name {
+            return params
+        }
     }
+    entries := make([]string, 0, len(env))
+    for _, e := range env {
+        entries = append(entries, e.Name+"="+e.Value)
+    }
+    i := sort.Search(len(params), func(i int) bool { return params[i].Name >= name })
+    params = append(params, pipelinev1.Param{})
+    copy(params[i+1:], params[i:])
+    params[i] = pipelinev1.Param{Name: name, Value: pipelinev1.ParamValue{Type: pipelinev1.ParamTypeArray, ArrayVal: entries}}
     return params
 }
 
+// ComputeResources converts spec.resources into ResourceRequirements, or nil when
+// no request is set.
+func ComputeResources(res *workloadv1alpha1.Resources) (*corev1.ResourceRequirements, error) {
+    if res == nil || res.Requests == nil {
+        return nil, nil
+    }
+    requests := corev1.ResourceList{}
+    for name, value := range map[corev1.ResourceName]string{
+        corev1.ResourceCPU:    res.Requests.CPU,
+        corev1.ResourceMemory: res.Requests.Memory,
+    } {
+        if value == "" {
+            continue
+        }
+        q, err := resource.ParseQuantity(value)
+        if err != nil {
+            return nil, fmt.Errorf("resources.requests.%s %q: %w", name, value, err)
+        }
+        requests[name] = q
+    }
+    if len(requests) == 0 {
+        return nil, nil
+    }
+    return &corev1.ResourceRequirements{Requests: requests}, nil
+}
+
+// TaskRunSpecs overrides the compute resources of every task in spec, including
+// finally tasks, through the PipelineRun's spec.taskRunSpecs.
+func TaskRunSpecs(spec *pipelinev1.PipelineSpec, resources *corev1.ResourceRequirements) []pipelinev1.PipelineTaskRunSpec {
+    if spec == nil || resources == nil {
+        return nil
+    }
+    var specs []pipelinev1.PipelineTaskRunSpec
+    for _, tasks := range [][]pipelinev1.PipelineTask{spec.Tasks, spec.Finally} {
+        for _, t := range tasks {
+            specs = append(specs, pipelinev1.PipelineTaskRunSpec{
+                PipelineTaskName: t.Name,
+                ComputeResources: resources.DeepCopy(),
+            })
+        }
+    }
+    return specs
+}
+
 // BuildWorkspaceBindings binds PVC and any existing Secret workspaces.
 func BuildWorkspaceBindings(ctx context.Context, cl client.Client, ns string,
-    pipelineWorkspaces []pipelinev1beta1.PipelineWorkspaceDeclaration,
+    pipelineWorkspaces []pipelinev1.PipelineWorkspaceDeclaration,
     currentPVCClaimName string,
-) ([]pipelinev1beta1.WorkspaceBinding, error) {
+) ([]pipelinev1.WorkspaceBinding, error) {
     logger := log.FromContext(ctx)
-    var wsBindings []pipelinev1beta1.WorkspaceBinding
+    var wsBindings []pipelinev1.WorkspaceBinding
     for _, decl := range pipelineWorkspaces {
         wsName := decl.Name
         if util.IsPvcWorkspace(wsName) {
-            wsBindings = append(wsBindings, pipelinev1beta1.WorkspaceBinding{
+            wsBindings = append(wsBindings, pipelinev1.WorkspaceBinding{
                 Name:                  wsName,
                 PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: currentPVCClaimName},
             })
         } else {
             secret := &corev1.Secret{}
             if err := cl.Get(ctx, client.ObjectKey{Namespace: ns, Name: wsName}, secret); err == nil {
-                wsBindings = append(wsBindings, pipelinev1beta1.WorkspaceBinding{
+                wsBindings = append(wsBindings, pipelinev1.WorkspaceBinding{
                     Name:   wsName,
                     Secret: &corev1.SecretVolumeSource{SecretName: wsName},
                 })
@@ -184,32 +398,62 @@ func BuildWorkspaceBindings(ctx context.Context, cl client.Client, ns string,
     return wsBindings, nil
 }
 
+// BuildInputsHash returns a short, stable hash of everything that decides the content
+// of a PipelineRun: the pipeline name, params, workspace bindings and task overrides.
+func BuildInputsHash(pipelineName string,
+    params []pipelinev1.Param,
+    wsBindings []pipelinev1.WorkspaceBinding,
+    taskRunSpecs []pipelinev1.PipelineTaskRunSpec,
+) (string, error) {
+    // taskRunSpecs 가 없으면 기존 해시와 같도록 생략합니다.
+    b, err := json.Marshal(struct {
+        Pipeline     string                           `json:"pipeline"`
+        Params       []pipelinev1.Param               `json:"params"`
+        Workspaces   []pipelinev1.WorkspaceBinding    `json:"workspaces"`
+        TaskRunSpecs []pipelinev1.PipelineTaskRunSpec `json:"taskRunSpecs,omitempty"`
+    }{pipelineName, params, wsBindings, taskRunSpecs})
+    if err != nil {
+        return "", fmt.Errorf("marshal build inputs: %w", err)
+    }
+    sum := sha256.Sum256(b)
+    return hex.EncodeToString(sum[:])[:16], nil
+}
+
+// PipelineRunName returns the deterministic PipelineRun name for a Workload build,
+// so that re-creating the same build is rejected with AlreadyExists.
+func PipelineRunName(workloadName, sha, buildHash string) string {
+    return fmt.Sprintf("%s-%s-%s", workloadName, sha[:7], buildHash[:6])
+}
+
 // NewPipelineRun constructs a PipelineRun with owner ref, params, workspaces, etc.
 func NewPipelineRun(
-    wl *unstructured.Unstructured,
-    ns, name, pipelineName string,
-    params []pipelinev1beta1.Param,
-    wsBindings []pipelinev1beta1.WorkspaceBinding,
-) *pipelinev1beta1.PipelineRun {
-    return &pipelinev1beta1.PipelineRun{
+    wl *workloadv1alpha1.Workload,
+    ns, name string,
+    pipelineRef *pipelinev1.PipelineRef,
+    params []pipelinev1.Param,
+    wsBindings []pipelinev1.WorkspaceBinding,
+) *pipelinev1.PipelineRun {
+    return &pipelinev1.PipelineRun{
         ObjectMeta: metav1.ObjectMeta{
-            Name:      fmt.Sprintf("%s-pr-%d", name, time.Now().Unix()),
+            Name:      name,
             Namespace: ns,
-            Labels:    map[string]string{WorkloadNameParam: name},
+            Labels:    map[string]string{WorkloadNameParam: wl.Name},
             OwnerReferences: []metav1.OwnerReference{{
-                APIVersion:         fmt.Sprintf("%s/%s", WorkloadApiGroupVersion.Group, WorkloadApiGroupVersion.Version),
+                APIVersion:         workloadv1alpha1.GroupVersion.String(),
                 Kind:               WorkloadKind,
-                Name:               name,
-                UID:                wl.GetUID(),
+                Name:               wl.Name,
+                UID:                wl.UID,
                 Controller:         boolPtr(true),
                 BlockOwnerDeletion: boolPtr(true),
             }},
         },
-        Spec: pipelinev1beta1.PipelineRunSpec{
-            PipelineRef:        &pipelinev1beta1.PipelineRef{Name: pipelineName},
-            Params:             params,
-            Workspaces:         wsBindings,
-            ServiceAccountName: DefaultServiceAccountName,
+        Spec: pipelinev1.PipelineRunSpec{
+            PipelineRef: pipelineRef,
+            Params:      params,
+            Workspaces:  wsBindings,
+            TaskRunTemplate: pipelinev1.PipelineTaskRunTemplate{
+                ServiceAccountName: DefaultServiceAccountName,
+            },
         },
     }
 }
@@ -2,15 +2,100 @@
 package pipeline
 
 import (
+	"strings"
 	"testing"
+
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	workloadv1alpha1 "tekton-controller/api/v1alpha1"
 )
 
+// stringValues wraps plain strings as string ParamValues.
+func stringValues(m map[string]string) map[string]pipelinev1.ParamValue {
+	values := make(map[string]pipelinev1.ParamValue, len(m))
+	for k, v := range m {
+		values[k] = *pipelinev1.NewStructuredValues(v)
+	}
+	return values
+}
+
 func TestBuildPipelineRunParams(t *testing.T) {
-	// TODO: Implement test
+	params := BuildPipelineRunParams(stringValues(map[string]string{"b": "2", "a": "1"}))
+	if len(params) != 2 || params[0].Name != "a" || params[1].Value.StringVal != "2" {
+		t.Errorf("expected params sorted by name, got %#v", params)
+	}
+}
+
+func TestParamMapFromSpec(t *testing.T) {
+	raw := func(s string) apiextensionsv1.JSON { return apiextensionsv1.JSON{Raw: []byte(s)} }
+	m := ParamMapFromSpec([]workloadv1alpha1.Param{
+		{Name: "str", Value: raw(`"main"`)},
+		{Name: "num", Value: raw(`1000000`)},
+		{Name: "flag", Value: raw(`true`)},
+		{Name: "list", Value: raw(`["a", "b", 3]`)},
+		{Name: "obj", Value: raw(`{"k": "v", "n": 1}`)},
+		{Name: "nested", Value: raw(`[{"name": "db", "type": "mysql"}]`)},
+	})
+
+	for name, want := range map[string]string{"str": "main", "num": "1000000", "flag": "true", "nested": `[{"name":"db","type":"mysql"}]`} {
+		if got := m[name]; got.Type != pipelinev1.ParamTypeString || got.StringVal != want {
+			t.Errorf("%s: expected string %q, got %#v", name, want, got)
+		}
+	}
+	if got := m["list"]; got.Type != pipelinev1.ParamTypeArray || strings.Join(got.ArrayVal, ",") != "a,b,3" {
+		t.Errorf("list: expected array [a b 3], got %#v", got)
+	}
+	if got := m["obj"]; got.Type != pipelinev1.ParamTypeObject || got.ObjectVal["k"] != "v" || got.ObjectVal["n"] != "1" {
+		t.Errorf("obj: expected object, got %#v", got)
+	}
+}
+
+func TestCheckParamTypes(t *testing.T) {
+	specs := pipelinev1.ParamSpecs{
+		{Name: "str", Type: pipelinev1.ParamTypeString},
+		{Name: "list", Type: pipelinev1.ParamTypeArray},
+	}
+	params := []pipelinev1.Param{
+		{Name: "list", Value: *pipelinev1.NewStructuredValues("a", "b")},
+		{Name: "str", Value: *pipelinev1.NewStructuredValues("a", "b")},
+		{Name: "undeclared", Value: *pipelinev1.NewObject(map[string]string{"k": "v"})},
+	}
+	got, err := CheckParamTypes(specs, params)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got[1].Value.Type != pipelinev1.ParamTypeString || got[1].Value.StringVal != `["a","b"]` {
+		t.Errorf("expected array passed as JSON to a string param, got %#v", got[1].Value)
+	}
+	if got[2].Value.Type != pipelinev1.ParamTypeObject {
+		t.Errorf("expected undeclared param unchanged, got %#v", got[2].Value)
+	}
+
+	params[0].Value = *pipelinev1.NewStructuredValues("a")
+	if _, err := CheckParamTypes(specs, params); err == nil || !strings.Contains(err.Error(), "list is declared as array but given string") {
+		t.Errorf("expected type mismatch error, got %v", err)
+	}
 }
 
 func TestNewPipelineRun(t *testing.T) {
-	// TODO: Implement test
+	wl := &workloadv1alpha1.Workload{}
+	wl.SetName("app")
+	wl.SetUID("uid-1")
+	pr := NewPipelineRun(wl, "team-a", "app-run", &pipelinev1.PipelineRef{Name: "master-ci-pipeline"}, BuildPipelineRunParams(stringValues(map[string]string{"a": "1"})), nil)
+
+	if pr.Spec.PipelineRef == nil || pr.Spec.PipelineRef.Name != "master-ci-pipeline" {
+		t.Errorf("expected pipelineRef master-ci-pipeline, got %#v", pr.Spec.PipelineRef)
+	}
+	if pr.Spec.TaskRunTemplate.ServiceAccountName != DefaultServiceAccountName {
+		t.Errorf("expected taskRunTemplate.serviceAccountName %q, got %q", DefaultServiceAccountName, pr.Spec.TaskRunTemplate.ServiceAccountName)
+	}
+	if pr.Labels[WorkloadNameParam] != "app" {
+		t.Errorf("expected workloadname label app, got %v", pr.Labels)
+	}
+	if len(pr.OwnerReferences) != 1 || pr.OwnerReferences[0].UID != "uid-1" || !*pr.OwnerReferences[0].Controller {
+		t.Errorf("expected controller owner reference to the Workload, got %#v", pr.OwnerReferences)
+	}
 }
 
 func TestBuildWorkspaceBindings(t *testing.T) {
@@ -20,3 +105,127 @@ func TestBuildWorkspaceBindings(t *testing.T) {
 func TestBuildPipelineParamsFromWorkload(t *testing.T) {
 	// TODO: Implement test
 }
+
+func TestBuildInputsHash(t *testing.T) {
+	params := BuildPipelineRunParams(stringValues(map[string]string{"b": "2", "a": "1"}))
+	h1, err := BuildInputsHash("master-ci-pipeline", params, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	h2, _ := BuildInputsHash("master-ci-pipeline", BuildPipelineRunParams(stringValues(map[string]string{"a": "1", "b": "2"})), nil, nil)
+	if h1 != h2 {
+		t.Errorf("expected stable hash for equal inputs, got '%s' and '%s'", h1, h2)
+	}
+	h3, _ := BuildInputsHash("master-ci-pipeline", BuildPipelineRunParams(stringValues(map[string]string{"a": "1", "b": "3"})), nil, nil)
+	if h1 == h3 {
+		t.Errorf("expected different hash when a param changes, got '%s' for both", h1)
+	}
+}
+
+func TestAddEnvParam(t *testing.T) {
+	params := BuildPipelineRunParams(stringValues(map[string]string{"a": "1", "z": "2"}))
+	env := []workloadv1alpha1.EnvVar{{Name: "BP_JVM_VERSION", Value: "17"}, {Name: "OPTS", Value: "a=b"}}
+
+	params = AddEnvParam(params, BuildEnvParam, env)
+	if len(params) != 3 || params[1].Name != BuildEnvParam {
+		t.Fatalf("expected %s inserted in sorted order, got %#v", BuildEnvParam, params)
+	}
+	got := params[1].Value
+	if got.Type != pipelinev1.ParamTypeArray || len(got.ArrayVal) != 2 || got.ArrayVal[1] != "OPTS=a=b" {
+		t.Errorf("expected array of NAME=VALUE entries, got %#v", got)
+	}
+
+	if again := AddEnvParam(params, BuildEnvParam, env); len(again) != 3 {
+		t.Errorf("expected an explicit param to be kept, got %d params", len(again))
+	}
+	if none := AddEnvParam(params, AppEnvParam, nil); len(none) != 3 {
+		t.Errorf("expected no param for empty env, got %d params", len(none))
+	}
+
+	// 항목이 하나여도 배열로 전달되어야 합니다.
+	one := AddEnvParam(nil, AppEnvParam, env[:1])
+	if len(one) != 1 || one[0].Value.Type != pipelinev1.ParamTypeArray || len(one[0].Value.ArrayVal) != 1 || one[0].Value.ArrayVal[0] != "BP_JVM_VERSION=17" {
+		t.Errorf("expected single entry array, got %#v", one)
+	}
+}
+
+func TestTaskRunSpecs(t *testing.T) {
+	res, err := ComputeResources(&workloadv1alpha1.Resources{Requests: &workloadv1alpha1.ResourceRequests{CPU: "500m"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Requests.Cpu().String() != "500m" || !res.Requests.Memory().IsZero() {
+		t.Errorf("expected only a cpu request of 500m, got %v", res.Requests)
+	}
+	if _, err := ComputeResources(&workloadv1alpha1.Resources{Requests: &workloadv1alpha1.ResourceRequests{Memory: "lots"}}); err == nil {
+		t.Errorf("expected error for invalid quantity")
+	}
+	if res, _ := ComputeResources(&workloadv1alpha1.Resources{}); res != nil {
+		t.Errorf("expected nil without requests, got %v", res)
+	}
+
+	spec := &pipelinev1.PipelineSpec{
+		Tasks:   []pipelinev1.PipelineTask{{Name: "fetch-source"}, {Name: "build"}},
+		Finally: []pipelinev1.PipelineTask{{Name: "notify"}},
+	}
+	specs := TaskRunSpecs(spec, res)
+	if len(specs) != 3 || specs[2].PipelineTaskName != "notify" {
+		t.Fatalf("expected a taskRunSpec per task, got %#v", specs)
+	}
+	if specs[1].ComputeResources.Requests.Cpu().String() != "500m" {
+		t.Errorf("expected cpu request on build, got %v", specs[1].ComputeResources)
+	}
+
+	params := BuildPipelineRunParams(stringValues(map[string]string{"a": "1"}))
+	h1, _ := BuildInputsHash("master-ci-pipeline", params, nil, nil)
+	h2, _ := BuildInputsHash("master-ci-pipeline", params, nil, specs)
+	if h1 == h2 {
+		t.Errorf("expected taskRunSpecs to change the build hash")
+	}
+}
+
+func TestReconcileParams(t *testing.T) {
+	specs := pipelinev1.ParamSpecs{
+		{Name: "ci-git-url", Type: pipelinev1.ParamTypeString},
+		{Name: "image_repo_address", Type: pipelinev1.ParamTypeString},
+		{Name: "submodules", Type: pipelinev1.ParamTypeString, Default: pipelinev1.NewStructuredValues("false")},
+	}
+	aliases := map[string]string{ImageRepoAddressParam: "image_repo_address"}
+	params := BuildPipelineRunParams(stringValues(map[string]string{
+		"ci-git-url":          "https://git.example.com/app.git",
+		ImageRepoAddressParam: "my-registry.io",
+		"unknown":             "x",
+	}))
+
+	got, ignored, err := ReconcileParams(specs, params, aliases)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var names []string
+	for _, p := range got {
+		names = append(names, p.Name+"="+p.Value.StringVal)
+	}
+	want := "ci-git-url=https://git.example.com/app.git,image_repo_address=my-registry.io,submodules=false"
+	if strings.Join(names, ",") != want {
+		t.Errorf("expected %s, got %s", want, strings.Join(names, ","))
+	}
+	if strings.Join(ignored, ",") != "unknown" {
+		t.Errorf("expected unknown to be ignored, got %v", ignored)
+	}
+
+	// 별칭이 없으면 필수 param 이 빠진 것으로 보고합니다.
+	_, ignored, err = ReconcileParams(specs, params, nil)
+	if err == nil || !strings.Contains(err.Error(), "image_repo_address") {
+		t.Errorf("expected missing image_repo_address error, got %v", err)
+	}
+	if strings.Join(ignored, ",") != ImageRepoAddressParam+",unknown" {
+		t.Errorf("expected %s and unknown to be ignored, got %v", ImageRepoAddressParam, ignored)
+	}
+}
+
+func TestPipelineRunName(t *testing.T) {
+	name := PipelineRunName("my-app", "0123456789abcdef0123456789abcdef01234567", "abcdef0123456789")
+	if name != "my-app-0123456-abcdef" {
+		t.Errorf("expected 'my-app-0123456-abcdef', got '%s'", name)
+	}
+}
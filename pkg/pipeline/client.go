@@ -0,0 +1,164 @@
+// File: pkg/pipeline/client.go
+package pipeline
+
+import (
+    "context"
+    "fmt"
+
+    pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+    pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+    "k8s.io/apimachinery/pkg/runtime/schema"
+    "k8s.io/apimachinery/pkg/types"
+    "knative.dev/pkg/apis"
+    "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// tekton.dev API versions the controller can read and write.
+const (
+    APIVersionV1      = "v1"
+    APIVersionV1beta1 = "v1beta1"
+)
+
+// cancelPatch sets spec.status to Cancelled; the field is the same in v1 and v1beta1.
+var cancelPatch = []byte(`{"spec":{"status":"` + pipelinev1.PipelineRunSpecStatusCancelled + `"}}`)
+
+// Client reads and writes Tekton resources as tekton.dev/v1 types. With Version
+// v1beta1 objects are converted on the way, for clusters that do not serve v1.
+type Client struct {
+    client.Client
+    Version string
+}
+
+// tektonObject is a Tekton resource that converts between API versions.
+type tektonObject interface {
+    client.Object
+    apis.Convertible
+}
+
+// NewClient returns a Client for the given tekton.dev version; empty means v1.
+func NewClient(c client.Client, version string) (*Client, error) {
+    switch version {
+    case "":
+        version = APIVersionV1
+    case APIVersionV1, APIVersionV1beta1:
+    default:
+        return nil, fmt.Errorf("unsupported tekton.dev API version %q, expected %s or %s",
+            version, APIVersionV1, APIVersionV1beta1)
+    }
+    return &Client{Client: c, Version: version}, nil
+}
+
+func (c *Client) v1beta1() bool { return c.Version == APIVersionV1beta1 }
+
+// GroupVersion returns the tekton.dev group version in use.
+func (c *Client) GroupVersion() schema.GroupVersion {
+    if c.v1beta1() {
+        return pipelinev1beta1.SchemeGroupVersion
+    }
+    return pipelinev1.SchemeGroupVersion
+}
+
+// NewPipelineRunObject returns an empty PipelineRun of the version in use, e.g. for watches.
+func (c *Client) NewPipelineRunObject() client.Object {
+    if c.v1beta1() {
+        return &pipelinev1beta1.PipelineRun{}
+    }
+    return &pipelinev1.PipelineRun{}
+}
+
+// get reads key into out, going through old and converting when v1beta1 is in use.
+func (c *Client) get(ctx context.Context, key client.ObjectKey, out, old tektonObject) error {
+    if !c.v1beta1() {
+        return c.Get(ctx, key, out)
+    }
+    if err := c.Get(ctx, key, old); err != nil {
+        return err
+    }
+    if err := old.ConvertTo(ctx, out); err != nil {
+        return fmt.Errorf("convert %T %s from v1beta1: %w", old, key, err)
+    }
+    return nil
+}
+
+// GetPipeline returns the Pipeline key.
+func (c *Client) GetPipeline(ctx context.Context, key client.ObjectKey) (*pipelinev1.Pipeline, error) {
+    pl := &pipelinev1.Pipeline{}
+    if err := c.get(ctx, key, pl, &pipelinev1beta1.Pipeline{}); err != nil {
+        return nil, err
+    }
+    return pl, nil
+}
+
+// GetPipelineRun returns the PipelineRun key.
+func (c *Client) GetPipelineRun(ctx context.Context, key client.ObjectKey) (*pipelinev1.PipelineRun, error) {
+    pr := &pipelinev1.PipelineRun{}
+    if err := c.get(ctx, key, pr, &pipelinev1beta1.PipelineRun{}); err != nil {
+        return nil, err
+    }
+    return pr, nil
+}
+
+// GetTaskRun returns the TaskRun key.
+func (c *Client) GetTaskRun(ctx context.Context, key client.ObjectKey) (*pipelinev1.TaskRun, error) {
+    tr := &pipelinev1.TaskRun{}
+    if err := c.get(ctx, key, tr, &pipelinev1beta1.TaskRun{}); err != nil {
+        return nil, err
+    }
+    return tr, nil
+}
+
+// ListPipelineRuns lists PipelineRuns matching opts.
+func (c *Client) ListPipelineRuns(ctx context.Context, opts ...client.ListOption) ([]pipelinev1.PipelineRun, error) {
+    if !c.v1beta1() {
+        list := &pipelinev1.PipelineRunList{}
+        if err := c.List(ctx, list, opts...); err != nil {
+            return nil, err
+        }
+        return list.Items, nil
+    }
+    list := &pipelinev1beta1.PipelineRunList{}
+    if err := c.List(ctx, list, opts...); err != nil {
+        return nil, err
+    }
+    runs := make([]pipelinev1.PipelineRun, len(list.Items))
+    for i := range list.Items {
+        if err := list.Items[i].ConvertTo(ctx, &runs[i]); err != nil {
+            return nil, fmt.Errorf("convert PipelineRun %s from v1beta1: %w", list.Items[i].Name, err)
+        }
+    }
+    return runs, nil
+}
+
+// CreatePipelineRun creates pr and updates its metadata from the server response.
+func (c *Client) CreatePipelineRun(ctx context.Context, pr *pipelinev1.PipelineRun) error {
+    if !c.v1beta1() {
+        return c.Create(ctx, pr)
+    }
+    old := &pipelinev1beta1.PipelineRun{}
+    if err := old.ConvertFrom(ctx, pr); err != nil {
+        return fmt.Errorf("convert PipelineRun %s to v1beta1: %w", pr.Name, err)
+    }
+    if err := c.Create(ctx, old); err != nil {
+        return err
+    }
+    pr.ObjectMeta = old.ObjectMeta
+    return nil
+}
+
+// CancelPipelineRun asks Tekton to cancel pr by setting spec.status to Cancelled.
+func (c *Client) CancelPipelineRun(ctx context.Context, pr *pipelinev1.PipelineRun) error {
+    return c.Patch(ctx, c.pipelineRunRef(pr), client.RawPatch(types.MergePatchType, cancelPatch))
+}
+
+// DeletePipelineRun deletes pr.
+func (c *Client) DeletePipelineRun(ctx context.Context, pr *pipelinev1.PipelineRun, opts ...client.DeleteOption) error {
+    return c.Delete(ctx, c.pipelineRunRef(pr), opts...)
+}
+
+// pipelineRunRef returns an object of the version in use naming pr, for patch and delete.
+func (c *Client) pipelineRunRef(pr *pipelinev1.PipelineRun) client.Object {
+    obj := c.NewPipelineRunObject()
+    obj.SetNamespace(pr.Namespace)
+    obj.SetName(pr.Name)
+    return obj
+}
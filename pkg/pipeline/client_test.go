@@ -0,0 +1,89 @@
+// File: pkg/pipeline/client_test.go
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	workloadv1alpha1 "tekton-controller/api/v1alpha1"
+)
+
+func TestNewClient(t *testing.T) {
+	c, err := NewClient(nil, "")
+	if err != nil || c.Version != APIVersionV1 {
+		t.Fatalf("expected v1 by default, got %v, %v", c, err)
+	}
+	if _, err := NewClient(nil, "v1alpha1"); err == nil {
+		t.Errorf("expected error for unsupported version")
+	}
+}
+
+func TestClient_V1beta1RoundTrip(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := pipelinev1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("add scheme: %v", err)
+	}
+	cli := fake.NewClientBuilder().WithScheme(scheme).Build()
+	c, err := NewClient(cli, APIVersionV1beta1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ctx := context.Background()
+
+	wl := &workloadv1alpha1.Workload{}
+	wl.SetName("app")
+	pr := NewPipelineRun(wl, "team-a", "app-run", &pipelinev1.PipelineRef{Name: "master-ci-pipeline"},
+		BuildPipelineRunParams(stringValues(map[string]string{"a": "1"})), nil)
+	if err := c.CreatePipelineRun(ctx, pr); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	// 클러스터에는 v1beta1 로 저장되고 serviceAccountName 으로 변환되어야 함
+	stored := &pipelinev1beta1.PipelineRun{}
+	if err := cli.Get(ctx, client.ObjectKey{Namespace: "team-a", Name: "app-run"}, stored); err != nil {
+		t.Fatalf("get v1beta1: %v", err)
+	}
+	if stored.Spec.ServiceAccountName != DefaultServiceAccountName {
+		t.Errorf("expected serviceAccountName %q, got %q", DefaultServiceAccountName, stored.Spec.ServiceAccountName)
+	}
+
+	got, err := c.GetPipelineRun(ctx, client.ObjectKey{Namespace: "team-a", Name: "app-run"})
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if got.Spec.TaskRunTemplate.ServiceAccountName != DefaultServiceAccountName || got.Spec.Params[0].Value.StringVal != "1" {
+		t.Errorf("unexpected converted spec %#v", got.Spec)
+	}
+
+	if err := c.CancelPipelineRun(ctx, got); err != nil {
+		t.Fatalf("cancel: %v", err)
+	}
+	runs, err := c.ListPipelineRuns(ctx, client.InNamespace("team-a"))
+	if err != nil || len(runs) != 1 || !runs[0].IsCancelled() {
+		t.Fatalf("expected one cancelled run, got %v, %v", runs, err)
+	}
+
+	if err := c.DeletePipelineRun(ctx, &runs[0]); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if runs, _ := c.ListPipelineRuns(ctx); len(runs) != 0 {
+		t.Errorf("expected no runs after delete, got %d", len(runs))
+	}
+}
+
+func TestClient_NewPipelineRunObject(t *testing.T) {
+	v1, _ := NewClient(nil, APIVersionV1)
+	if _, ok := v1.NewPipelineRunObject().(*pipelinev1.PipelineRun); !ok {
+		t.Errorf("expected v1 PipelineRun")
+	}
+	beta, _ := NewClient(nil, APIVersionV1beta1)
+	if _, ok := beta.NewPipelineRunObject().(*pipelinev1beta1.PipelineRun); !ok {
+		t.Errorf("expected v1beta1 PipelineRun")
+	}
+}
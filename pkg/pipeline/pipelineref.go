@@ -0,0 +1,86 @@
+// File: pkg/pipeline/pipelineref.go
+package pipeline
+
+import (
+    "fmt"
+    "sort"
+    "strings"
+
+    pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+// Tekton remote resolvers a Pipeline can be referenced through.
+const (
+    // BundlesResolver fetches a Pipeline from an OCI bundle.
+    BundlesResolver = "bundles"
+    // ClusterResolver fetches a Pipeline from another namespace.
+    ClusterResolver = "cluster"
+    // GitResolver fetches a Pipeline from a file in a Git repository.
+    GitResolver = "git"
+)
+
+// Resolvers lists the supported Tekton remote resolvers.
+var Resolvers = []string{BundlesResolver, GitResolver, ClusterResolver}
+
+// ResolverPipelineRef returns a PipelineRef resolving through resolver with params.
+// Params are sorted by name so that equal references compare equal.
+func ResolverPipelineRef(resolver string, params map[string]string) *pipelinev1.PipelineRef {
+    names := make([]string, 0, len(params))
+    for name := range params {
+        names = append(names, name)
+    }
+    sort.Strings(names)
+    ref := &pipelinev1.PipelineRef{ResolverRef: pipelinev1.ResolverRef{Resolver: pipelinev1.ResolverName(resolver)}}
+    for _, name := range names {
+        ref.Params = append(ref.Params, pipelinev1.Param{Name: name, Value: *pipelinev1.NewStructuredValues(params[name])})
+    }
+    return ref
+}
+
+// ResolverID identifies a resolver PipelineRef in the build inputs hash.
+func ResolverID(ref *pipelinev1.PipelineRef) string {
+    pairs := make([]string, 0, len(ref.Params))
+    for _, p := range ref.Params {
+        pairs = append(pairs, p.Name+"="+p.Value.StringVal)
+    }
+    return fmt.Sprintf("%s:%s", ref.Resolver, strings.Join(pairs, ","))
+}
+
+// ClusterResolverRef returns a PipelineRef resolving Pipeline name in namespace
+// through the Tekton cluster resolver.
+func ClusterResolverRef(namespace, name string) *pipelinev1.PipelineRef {
+    return ResolverPipelineRef(ClusterResolver, map[string]string{
+        "kind":      "pipeline",
+        "name":      name,
+        "namespace": namespace,
+    })
+}
+
+// WorkspaceDeclarations declares the named workspaces, for Pipelines whose spec
+// the controller does not read.
+func WorkspaceDeclarations(names []string) []pipelinev1.PipelineWorkspaceDeclaration {
+    decls := make([]pipelinev1.PipelineWorkspaceDeclaration, 0, len(names))
+    for _, name := range names {
+        decls = append(decls, pipelinev1.PipelineWorkspaceDeclaration{Name: name})
+    }
+    return decls
+}
+
+// CheckCompatible reports the workspaces spec requires that are missing from bindings.
+// Params are reconciled separately by ReconcileParams.
+func CheckCompatible(spec *pipelinev1.PipelineSpec, bindings []pipelinev1.WorkspaceBinding) error {
+    bound := make(map[string]bool, len(bindings))
+    for _, b := range bindings {
+        bound[b.Name] = true
+    }
+    var missing []string
+    for _, ws := range spec.Workspaces {
+        if !ws.Optional && !bound[ws.Name] {
+            missing = append(missing, ws.Name)
+        }
+    }
+    if len(missing) > 0 {
+        return fmt.Errorf("workspaces %s cannot be bound", strings.Join(missing, ", "))
+    }
+    return nil
+}
@@ -0,0 +1,70 @@
+// File: pkg/pipeline/pipelineref_test.go
+package pipeline
+
+import (
+	"strings"
+	"testing"
+
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+func TestCheckCompatible(t *testing.T) {
+	spec := &pipelinev1.PipelineSpec{
+		Params: pipelinev1.ParamSpecs{{Name: "ci-git-url"}},
+		Workspaces: []pipelinev1.PipelineWorkspaceDeclaration{
+			{Name: "shared-data"},
+			{Name: "settings-xml", Optional: true},
+		},
+	}
+
+	if err := CheckCompatible(spec, []pipelinev1.WorkspaceBinding{{Name: "shared-data"}}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	err := CheckCompatible(spec, nil)
+	if err == nil || !strings.Contains(err.Error(), "shared-data") {
+		t.Fatalf("expected unbound workspace error, got %v", err)
+	}
+	if strings.Contains(err.Error(), "settings-xml") {
+		t.Errorf("optional workspace must not be reported, got %v", err)
+	}
+}
+
+func TestClusterResolverRef(t *testing.T) {
+	ref := ClusterResolverRef("tekton-catalog", "buildpacks")
+	if ref.Resolver != ClusterResolver || ref.Name != "" {
+		t.Fatalf("expected cluster resolver ref, got %#v", ref)
+	}
+	got := map[string]string{}
+	for _, p := range ref.Params {
+		got[p.Name] = p.Value.StringVal
+	}
+	want := map[string]string{"kind": "pipeline", "name": "buildpacks", "namespace": "tekton-catalog"}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("param %s: expected %q, got %q", k, v, got[k])
+		}
+	}
+}
+
+func TestResolverPipelineRef(t *testing.T) {
+	ref := ResolverPipelineRef(BundlesResolver, map[string]string{
+		"name":   "master-ci-pipeline",
+		"bundle": "registry.io/ci/pipelines:v1",
+		"kind":   "pipeline",
+	})
+	if ref.Resolver != BundlesResolver {
+		t.Fatalf("expected bundles resolver, got %q", ref.Resolver)
+	}
+	var names []string
+	for _, p := range ref.Params {
+		names = append(names, p.Name)
+	}
+	if got := strings.Join(names, ","); got != "bundle,kind,name" {
+		t.Errorf("expected params sorted by name, got %s", got)
+	}
+	want := "bundles:bundle=registry.io/ci/pipelines:v1,kind=pipeline,name=master-ci-pipeline"
+	if got := ResolverID(ref); got != want {
+		t.Errorf("expected ID %q, got %q", want, got)
+	}
+}
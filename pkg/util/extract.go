@@ -22,6 +22,28 @@ func ExtractProjectName(repoURL string) string {
         return parts[len(parts)-1]
 }
 
+// NormalizeRepoURL은 서로 다른 형태의 Git URL을 비교할 수 있도록 "host/path" 형태로 정규화합니다.
+// 예: https://user@GitLab.com/group/app.git, git@gitlab.com:group/app.git → gitlab.com/group/app
+func NormalizeRepoURL(repoURL string) string {
+        u := strings.ToLower(strings.TrimSpace(repoURL))
+        u = strings.TrimSuffix(u, "/")
+        u = strings.TrimSuffix(u, ".git")
+        if i := strings.Index(u, "://"); i >= 0 {
+                u = u[i+3:]
+        } else if strings.Contains(u, "@") {
+                // scp 형식 (git@host:group/repo)
+                u = strings.Replace(u, ":", "/", 1)
+        }
+        host, path, _ := strings.Cut(u, "/")
+        if i := strings.LastIndex(host, "@"); i >= 0 {
+                host = host[i+1:]
+        }
+        if i := strings.Index(host, ":"); i >= 0 {
+                host = host[:i]
+        }
+        return host + "/" + path
+}
+
 // IsPvcWorkspace는 워크스페이스 이름에 따라 PVC 사용 여부를 결정합니다.
 func IsPvcWorkspace(wsName string) bool {
         lower := strings.ToLower(wsName)
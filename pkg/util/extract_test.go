@@ -24,3 +24,24 @@ func TestExtractProjectName(t *testing.T) {
                 })
         }
 }
+
+func TestNormalizeRepoURL(t *testing.T) {
+        testCases := []struct {
+                name     string
+                repoURL  string
+                expected string
+        }{
+                {"HTTPS with .git", "https://gitlab.com/group/my-project.git", "gitlab.com/group/my-project"},
+                {"HTTPS with user and port", "https://oauth2@GitLab.com:443/group/my-project", "gitlab.com/group/my-project"},
+                {"SCP-like SSH", "git@gitlab.com:group/my-project.git", "gitlab.com/group/my-project"},
+                {"SSH URL with port", "ssh://git@gitlab.com:2222/group/my-project.git", "gitlab.com/group/my-project"},
+        }
+
+        for _, tc := range testCases {
+                t.Run(tc.name, func(t *testing.T) {
+                        if actual := NormalizeRepoURL(tc.repoURL); actual != tc.expected {
+                                t.Errorf("expected '%s', got '%s'", tc.expected, actual)
+                        }
+                })
+        }
+}
@@ -2,12 +2,12 @@
 package util
 
 import (
-	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-// GetAnnotationOrDefault는 Unstructured 객체에서 특정 Annotation 값을 조회합니다.
+// GetAnnotationOrDefault는 객체에서 특정 Annotation 값을 조회합니다.
 // Annotation이 없거나 비어있으면 지정된 기본값을 반환합니다.
-func GetAnnotationOrDefault(obj *unstructured.Unstructured, annotationKey, defaultValue string) string {
+func GetAnnotationOrDefault(obj metav1.Object, annotationKey, defaultValue string) string {
 	annotations := obj.GetAnnotations()
 	if annotations == nil {
 		return defaultValue
@@ -20,7 +20,7 @@ func GetAnnotationOrDefault(obj *unstructured.Unstructured, annotationKey, defau
 
 // EnsureFinalizer는 객체에 특정 파이널라이저가 없으면 추가합니다.
 // 변경이 일어났을 경우 true를 반환합니다.
-func EnsureFinalizer(obj *unstructured.Unstructured, finalizerName string) bool {
+func EnsureFinalizer(obj metav1.Object, finalizerName string) bool {
 	finalizers := obj.GetFinalizers()
 	for _, f := range finalizers {
 		if f == finalizerName {
@@ -35,7 +35,7 @@ func EnsureFinalizer(obj *unstructured.Unstructured, finalizerName string) bool
 
 // RemoveFinalizer는 객체에서 특정 파이널라이저를 제거합니다.
 // 변경이 일어났을 경우 true를 반환합니다.
-func RemoveFinalizer(obj *unstructured.Unstructured, finalizerName string) bool {
+func RemoveFinalizer(obj metav1.Object, finalizerName string) bool {
 	finalizers := obj.GetFinalizers()
 	var newFinalizers []string
 	var found bool
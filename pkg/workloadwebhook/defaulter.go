@@ -0,0 +1,85 @@
+// File: pkg/workloadwebhook/defaulter.go
+package workloadwebhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	workloadv1alpha1 "tekton-controller/api/v1alpha1"
+	"tekton-controller/pkg/config"
+	"tekton-controller/pkg/pipeline"
+)
+
+// +kubebuilder:webhook:path=/mutate-tekton-platform-v1alpha1-workload,mutating=true,failurePolicy=fail,sideEffects=None,groups=tekton.platform,resources=workloads,verbs=create,versions=v1alpha1,name=mworkload.tekton.platform,admissionReviewVersions=v1
+
+// Defaulter writes the configured defaults into new Workloads so the effective
+// image repository, Git secret and workspace claim are visible on the object.
+type Defaulter struct {
+	// Config is read on every request so reloaded defaults apply immediately.
+	Config *config.Store
+}
+
+var _ admission.CustomDefaulter = &Defaulter{}
+
+// Default implements admission.CustomDefaulter. Only creates are defaulted so
+// that changing the controller configuration never rewrites existing Workloads.
+func (d *Defaulter) Default(ctx context.Context, obj runtime.Object) error {
+	wl, ok := obj.(*workloadv1alpha1.Workload)
+	if !ok {
+		return fmt.Errorf("expected a Workload but got %T", obj)
+	}
+	if req, err := admission.RequestFromContext(ctx); err == nil && req.Operation != admissionv1.Create {
+		return nil
+	}
+	return ApplyDefaults(wl, d.Config.Get().Defaults)
+}
+
+// ApplyDefaults sets every default wl does not set explicitly.
+func ApplyDefaults(wl *workloadv1alpha1.Workload, defaults config.Defaults) error {
+	annotations := wl.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	// A token replaces the Git secret, so there is nothing to default.
+	if _, ok := annotations[workloadv1alpha1.AnnotationBuildGitToken]; !ok {
+		setIfAbsent(annotations, workloadv1alpha1.AnnotationBuildGitSecret, defaults.GitSecretName)
+	}
+	setIfAbsent(annotations, workloadv1alpha1.AnnotationBuildWorkspaceClaim, defaults.WorkspaceClaimName)
+	wl.SetAnnotations(annotations)
+
+	for _, p := range []struct{ name, value string }{
+		{pipeline.ImageRepoAddressParam, defaults.ImageRepoAddress},
+		{pipeline.ImageRepoPathParam, defaults.ImageRepoPath},
+	} {
+		if p.value == "" || hasParam(wl.Spec.Params, p.name) {
+			continue
+		}
+		raw, err := json.Marshal(p.value)
+		if err != nil {
+			return fmt.Errorf("marshal default for param %q: %w", p.name, err)
+		}
+		wl.Spec.Params = append(wl.Spec.Params, workloadv1alpha1.Param{Name: p.name, Value: apiextensionsv1.JSON{Raw: raw}})
+	}
+	return nil
+}
+
+func setIfAbsent(m map[string]string, key, value string) {
+	if _, ok := m[key]; !ok && value != "" {
+		m[key] = value
+	}
+}
+
+func hasParam(params []workloadv1alpha1.Param, name string) bool {
+	for _, p := range params {
+		if p.Name == name {
+			return true
+		}
+	}
+	return false
+}
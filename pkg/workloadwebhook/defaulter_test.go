@@ -0,0 +1,54 @@
+// File: pkg/workloadwebhook/defaulter_test.go
+package workloadwebhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	workloadv1alpha1 "tekton-controller/api/v1alpha1"
+	"tekton-controller/pkg/config"
+	"tekton-controller/pkg/pipeline"
+)
+
+func TestDefaulter_Create(t *testing.T) {
+	wl := validWorkload()
+	wl.Spec.Params = []workloadv1alpha1.Param{param(pipeline.ImageRepoPathParam, `"team-a"`)}
+	wl.SetAnnotations(map[string]string{workloadv1alpha1.AnnotationBuildWorkspaceClaim: "my-claim"})
+
+	d := &Defaulter{Config: config.NewStore(config.Default())}
+	ctx := admission.NewContextWithRequest(context.Background(), admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{Operation: admissionv1.Create},
+	})
+	assert.NoError(t, d.Default(ctx, wl))
+
+	assert.Equal(t, map[string]string{
+		workloadv1alpha1.AnnotationBuildGitSecret:      "git-credentials",
+		workloadv1alpha1.AnnotationBuildWorkspaceClaim: "my-claim",
+	}, wl.GetAnnotations())
+	// 사용자가 지정한 image-repo-path 는 유지하고 image-repo-address 만 추가
+	assert.Equal(t, []workloadv1alpha1.Param{
+		param(pipeline.ImageRepoPathParam, `"team-a"`),
+		param(pipeline.ImageRepoAddressParam, `"my-registry.io"`),
+	}, wl.Spec.Params)
+}
+
+func TestDefaulter_SkipsUpdatesAndTokenAuth(t *testing.T) {
+	d := &Defaulter{Config: config.NewStore(config.Default())}
+
+	wl := validWorkload()
+	ctx := admission.NewContextWithRequest(context.Background(), admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{Operation: admissionv1.Update},
+	})
+	assert.NoError(t, d.Default(ctx, wl))
+	assert.Empty(t, wl.GetAnnotations())
+	assert.Empty(t, wl.Spec.Params)
+
+	wl.SetAnnotations(map[string]string{workloadv1alpha1.AnnotationBuildGitToken: "t0ken"})
+	assert.NoError(t, ApplyDefaults(wl, config.Default().Defaults))
+	_, hasSecret := wl.GetAnnotations()[workloadv1alpha1.AnnotationBuildGitSecret]
+	assert.False(t, hasSecret, "git secret must not be defaulted when a token is set")
+}
@@ -0,0 +1,299 @@
+// File: pkg/workloadwebhook/validator.go
+package workloadwebhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	workloadv1alpha1 "tekton-controller/api/v1alpha1"
+	"tekton-controller/pkg/config"
+	"tekton-controller/pkg/git"
+	"tekton-controller/pkg/pipeline"
+)
+
+// +kubebuilder:webhook:path=/validate-tekton-platform-v1alpha1-workload,mutating=false,failurePolicy=fail,sideEffects=None,groups=tekton.platform,resources=workloads,verbs=create;update,versions=v1alpha1,name=vworkload.tekton.platform,admissionReviewVersions=v1
+
+// Validator rejects Workloads the reconciler could never build.
+type Validator struct{}
+
+var _ admission.CustomValidator = &Validator{}
+
+// SetupWithManager registers the Workload admission webhooks on mgr's webhook server.
+func SetupWithManager(mgr ctrl.Manager, cfg *config.Store) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&workloadv1alpha1.Workload{}).
+		WithDefaulter(&Defaulter{Config: cfg}).
+		WithValidator(&Validator{}).
+		Complete()
+}
+
+// ValidateCreate implements admission.CustomValidator.
+func (v *Validator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, validate(obj)
+}
+
+// ValidateUpdate implements admission.CustomValidator.
+func (v *Validator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	wl, ok := newObj.(*workloadv1alpha1.Workload)
+	if !ok {
+		return nil, fmt.Errorf("expected a Workload but got %T", newObj)
+	}
+	old, ok := oldObj.(*workloadv1alpha1.Workload)
+	if !ok {
+		return nil, fmt.Errorf("expected a Workload but got %T", oldObj)
+	}
+	// Workloads being deleted must still be able to drop their finalizer.
+	if !wl.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
+	// Metadata-only updates (finalizers, labels) must not be blocked by a spec
+	// that was accepted before these rules existed.
+	if equality.Semantic.DeepEqual(old.Spec, wl.Spec) {
+		return nil, nil
+	}
+	return nil, validate(wl)
+}
+
+// ValidateDelete implements admission.CustomValidator.
+func (v *Validator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validate(obj runtime.Object) error {
+	wl, ok := obj.(*workloadv1alpha1.Workload)
+	if !ok {
+		return fmt.Errorf("expected a Workload but got %T", obj)
+	}
+	if errs := ValidateWorkload(wl); len(errs) > 0 {
+		return apierrors.NewInvalid(workloadv1alpha1.GroupVersion.WithKind("Workload").GroupKind(), wl.Name, errs)
+	}
+	return nil
+}
+
+// ValidateWorkload returns every problem found in wl, with field paths.
+func ValidateWorkload(wl *workloadv1alpha1.Workload) field.ErrorList {
+	var errs field.ErrorList
+	errs = append(errs, validateAnnotations(wl.GetAnnotations(), field.NewPath("metadata", "annotations"))...)
+
+	spec := field.NewPath("spec")
+	errs = append(errs, validateSource(wl.Spec.Source, spec.Child("source"))...)
+	errs = append(errs, validateBuild(wl.Spec.Build, spec.Child("build"))...)
+	errs = append(errs, validateEnv(wl.Spec.Env, spec.Child("env"))...)
+	errs = append(errs, validateResources(wl.Spec.Resources, spec.Child("resources"))...)
+	errs = append(errs, validatePipelineRef(wl.Spec.PipelineRef, spec.Child("pipelineRef"))...)
+	errs = append(errs, validateParams(wl.Spec.Params, spec.Child("params"))...)
+	return errs
+}
+
+func validateAnnotations(annotations map[string]string, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	for _, key := range []string{workloadv1alpha1.AnnotationBuildGitSecret, workloadv1alpha1.AnnotationBuildWorkspaceClaim} {
+		value, ok := annotations[key]
+		if !ok {
+			continue
+		}
+		for _, msg := range validation.IsDNS1123Subdomain(value) {
+			errs = append(errs, field.Invalid(path.Key(key), value, msg))
+		}
+	}
+	return errs
+}
+
+func validateSource(src *workloadv1alpha1.Source, path *field.Path) field.ErrorList {
+	if src == nil {
+		return field.ErrorList{field.Required(path, "source is required")}
+	}
+	gitPath := path.Child("git")
+	if src.Git == nil {
+		return field.ErrorList{field.Required(gitPath, "git source is required")}
+	}
+
+	var errs field.ErrorList
+	if src.Git.URL == "" {
+		errs = append(errs, field.Required(gitPath.Child("url"), ""))
+	} else if err := git.ValidateRepoURL(src.Git.URL); err != nil {
+		errs = append(errs, field.Invalid(gitPath.Child("url"), src.Git.URL, err.Error()))
+	}
+
+	refPath := gitPath.Child("ref")
+	ref := src.Git.Ref
+	if ref == (workloadv1alpha1.GitRef{}) {
+		errs = append(errs, field.Required(refPath.Child("branch"), "one of branch, tag, commit or semver must be set"))
+	} else if err := git.RefFromSpec(ref).Validate(); err != nil {
+		errs = append(errs, field.Invalid(refPath, ref, err.Error()))
+	}
+
+	if d := src.Git.PollInterval; d != nil && d.Duration < 0 {
+		errs = append(errs, field.Invalid(gitPath.Child("pollInterval"), d.Duration.String(), "must not be negative"))
+	}
+	return errs
+}
+
+var concurrencyPolicies = []string{
+	string(workloadv1alpha1.ConcurrencyAllow),
+	string(workloadv1alpha1.ConcurrencyForbid),
+	string(workloadv1alpha1.ConcurrencyReplace),
+}
+
+func validateBuild(build *workloadv1alpha1.BuildSpec, path *field.Path) field.ErrorList {
+	if build == nil {
+		return nil
+	}
+	errs := validateEnv(build.Env, path.Child("env"))
+	if p := build.ConcurrencyPolicy; p != "" && !slices.Contains(concurrencyPolicies, string(p)) {
+		errs = append(errs, field.NotSupported(path.Child("concurrencyPolicy"), p, concurrencyPolicies))
+	}
+	if h := build.History; h != nil {
+		historyPath := path.Child("history")
+		if l := h.SuccessfulRunsLimit; l != nil && *l < 0 {
+			errs = append(errs, field.Invalid(historyPath.Child("successfulRunsLimit"), *l, "must not be negative"))
+		}
+		if l := h.FailedRunsLimit; l != nil && *l < 0 {
+			errs = append(errs, field.Invalid(historyPath.Child("failedRunsLimit"), *l, "must not be negative"))
+		}
+	}
+	return errs
+}
+
+func validateEnv(env []workloadv1alpha1.EnvVar, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	seen := make(map[string]struct{}, len(env))
+	for i, e := range env {
+		namePath := path.Index(i).Child("name")
+		if e.Name == "" {
+			errs = append(errs, field.Required(namePath, ""))
+			continue
+		}
+		for _, msg := range validation.IsEnvVarName(e.Name) {
+			errs = append(errs, field.Invalid(namePath, e.Name, msg))
+		}
+		if _, dup := seen[e.Name]; dup {
+			errs = append(errs, field.Duplicate(namePath, e.Name))
+		}
+		seen[e.Name] = struct{}{}
+	}
+	return errs
+}
+
+func validateResources(res *workloadv1alpha1.Resources, path *field.Path) field.ErrorList {
+	if res == nil || res.Requests == nil {
+		return nil
+	}
+	var errs field.ErrorList
+	requestsPath := path.Child("requests")
+	for _, q := range []struct{ name, value string }{{"cpu", res.Requests.CPU}, {"memory", res.Requests.Memory}} {
+		if q.value == "" {
+			continue
+		}
+		if _, err := resource.ParseQuantity(q.value); err != nil {
+			errs = append(errs, field.Invalid(requestsPath.Child(q.name), q.value, err.Error()))
+		}
+	}
+	return errs
+}
+
+func validatePipelineRef(ref *workloadv1alpha1.PipelineRef, path *field.Path) field.ErrorList {
+	if ref == nil {
+		return nil
+	}
+	set := 0
+	for _, ok := range []bool{ref.Name != "", ref.Catalog != "", ref.Resolver != nil} {
+		if ok {
+			set++
+		}
+	}
+	switch {
+	case set == 0:
+		return field.ErrorList{field.Required(path, "one of name, catalog or resolver must be set")}
+	case set > 1:
+		return field.ErrorList{field.Forbidden(path, "only one of name, catalog or resolver may be set")}
+	}
+	var errs field.ErrorList
+	for _, f := range []struct{ name, value string }{{"name", ref.Name}, {"catalog", ref.Catalog}} {
+		if f.value == "" {
+			continue
+		}
+		for _, msg := range validation.IsDNS1123Subdomain(f.value) {
+			errs = append(errs, field.Invalid(path.Child(f.name), f.value, msg))
+		}
+	}
+	if r := ref.Resolver; r != nil {
+		rPath := path.Child("resolver")
+		if !slices.Contains(pipeline.Resolvers, r.Resolver) {
+			errs = append(errs, field.NotSupported(rPath.Child("resolver"), r.Resolver, pipeline.Resolvers))
+		}
+		if len(r.Params) == 0 {
+			errs = append(errs, field.Required(rPath.Child("params"), "resolvers need params to locate the Pipeline"))
+		}
+		seen := make(map[string]struct{}, len(r.Params))
+		for i, p := range r.Params {
+			namePath := rPath.Child("params").Index(i).Child("name")
+			if p.Name == "" {
+				errs = append(errs, field.Required(namePath, ""))
+			} else if _, dup := seen[p.Name]; dup {
+				errs = append(errs, field.Duplicate(namePath, p.Name))
+			}
+			seen[p.Name] = struct{}{}
+		}
+		for i, ws := range r.Workspaces {
+			for _, msg := range validation.IsDNS1123Label(ws) {
+				errs = append(errs, field.Invalid(rPath.Child("workspaces").Index(i), ws, msg))
+			}
+		}
+	}
+	return errs
+}
+
+func validateParams(params []workloadv1alpha1.Param, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	seen := make(map[string]struct{}, len(params))
+	for i, p := range params {
+		namePath := path.Index(i).Child("name")
+		if p.Name == "" {
+			errs = append(errs, field.Required(namePath, ""))
+			continue
+		}
+		if _, dup := seen[p.Name]; dup {
+			errs = append(errs, field.Duplicate(namePath, p.Name))
+			continue
+		}
+		seen[p.Name] = struct{}{}
+
+		if p.Name == pipeline.BuildServiceBindingsParam {
+			errs = append(errs, validateServiceBindings(p, path.Index(i).Child("value"))...)
+		}
+	}
+	return errs
+}
+
+func validateServiceBindings(p workloadv1alpha1.Param, path *field.Path) field.ErrorList {
+	if len(p.Value.Raw) == 0 {
+		return nil
+	}
+	var bindings []pipeline.ServiceBinding
+	if err := json.Unmarshal(p.Value.Raw, &bindings); err != nil {
+		return field.ErrorList{field.Invalid(path, string(p.Value.Raw), "must be a list of service bindings")}
+	}
+	var errs field.ErrorList
+	for i, sb := range bindings {
+		if sb.Name == "" {
+			errs = append(errs, field.Required(path.Index(i).Child("name"), "service binding name is required"))
+			continue
+		}
+		for _, msg := range validation.IsDNS1123Subdomain(sb.Name) {
+			errs = append(errs, field.Invalid(path.Index(i).Child("name"), sb.Name, msg))
+		}
+	}
+	return errs
+}
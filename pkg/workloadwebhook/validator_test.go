@@ -0,0 +1,165 @@
+// File: pkg/workloadwebhook/validator_test.go
+package workloadwebhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	workloadv1alpha1 "tekton-controller/api/v1alpha1"
+)
+
+func validWorkload() *workloadv1alpha1.Workload {
+	wl := &workloadv1alpha1.Workload{}
+	wl.SetNamespace("team-a")
+	wl.SetName("app")
+	wl.Spec.Source = &workloadv1alpha1.Source{Git: &workloadv1alpha1.GitSource{
+		URL: "https://gitlab.example.com/group/app.git",
+		Ref: workloadv1alpha1.GitRef{Branch: "main"},
+	}}
+	return wl
+}
+
+func param(name, raw string) workloadv1alpha1.Param {
+	return workloadv1alpha1.Param{Name: name, Value: apiextensionsv1.JSON{Raw: []byte(raw)}}
+}
+
+func TestValidateWorkload(t *testing.T) {
+	testCases := []struct {
+		name   string
+		mutate func(wl *workloadv1alpha1.Workload)
+		fields []string
+	}{
+		{name: "valid", mutate: func(*workloadv1alpha1.Workload) {}},
+		{
+			name:   "missing git source",
+			mutate: func(wl *workloadv1alpha1.Workload) { wl.Spec.Source.Git = nil },
+			fields: []string{"spec.source.git"},
+		},
+		{
+			name:   "empty branch",
+			mutate: func(wl *workloadv1alpha1.Workload) { wl.Spec.Source.Git.Ref.Branch = "" },
+			fields: []string{"spec.source.git.ref.branch"},
+		},
+		{
+			name:   "scp-like url",
+			mutate: func(wl *workloadv1alpha1.Workload) { wl.Spec.Source.Git.URL = "git@gitlab.example.com:group/app.git" },
+		},
+		{
+			name:   "bad url",
+			mutate: func(wl *workloadv1alpha1.Workload) { wl.Spec.Source.Git.URL = "not a url" },
+			fields: []string{"spec.source.git.url"},
+		},
+		{
+			name: "duplicate params",
+			mutate: func(wl *workloadv1alpha1.Workload) {
+				wl.Spec.Params = []workloadv1alpha1.Param{param("a", `"1"`), param("a", `"2"`)}
+			},
+			fields: []string{"spec.params[1].name"},
+		},
+		{
+			name: "service binding without name",
+			mutate: func(wl *workloadv1alpha1.Workload) {
+				wl.Spec.Params = []workloadv1alpha1.Param{param("buildServiceBindings", `[{"name":"db"},{"type":"mysql"}]`)}
+			},
+			fields: []string{"spec.params[0].value[1].name"},
+		},
+		{
+			name: "unknown concurrency policy",
+			mutate: func(wl *workloadv1alpha1.Workload) {
+				wl.Spec.Build = &workloadv1alpha1.BuildSpec{ConcurrencyPolicy: "Queue"}
+			},
+			fields: []string{"spec.build.concurrencyPolicy"},
+		},
+		{
+			name: "negative history limit",
+			mutate: func(wl *workloadv1alpha1.Workload) {
+				limit := int32(-1)
+				wl.Spec.Build = &workloadv1alpha1.BuildSpec{History: &workloadv1alpha1.BuildHistory{FailedRunsLimit: &limit}}
+			},
+			fields: []string{"spec.build.history.failedRunsLimit"},
+		},
+		{
+			name: "pipelineRef with name and catalog",
+			mutate: func(wl *workloadv1alpha1.Workload) {
+				wl.Spec.PipelineRef = &workloadv1alpha1.PipelineRef{Name: "a", Catalog: "b"}
+			},
+			fields: []string{"spec.pipelineRef"},
+		},
+		{
+			name: "invalid pipelineRef resolver",
+			mutate: func(wl *workloadv1alpha1.Workload) {
+				wl.Spec.PipelineRef = &workloadv1alpha1.PipelineRef{Resolver: &workloadv1alpha1.ResolverRef{
+					Resolver: "hub",
+					Params: []workloadv1alpha1.ResolverParam{
+						{Name: "name", Value: "a"},
+						{Name: "name", Value: "b"},
+					},
+				}}
+			},
+			fields: []string{"spec.pipelineRef.resolver.resolver", "spec.pipelineRef.resolver.params[1].name"},
+		},
+		{
+			name: "invalid env and resources",
+			mutate: func(wl *workloadv1alpha1.Workload) {
+				wl.Spec.Build = &workloadv1alpha1.BuildSpec{Env: []workloadv1alpha1.EnvVar{{Name: "BP_JVM_VERSION", Value: "17"}, {Name: "BP_JVM_VERSION"}}}
+				wl.Spec.Env = []workloadv1alpha1.EnvVar{{Name: "1BAD"}}
+				wl.Spec.Resources = &workloadv1alpha1.Resources{Requests: &workloadv1alpha1.ResourceRequests{CPU: "500m", Memory: "lots"}}
+			},
+			fields: []string{"spec.build.env[1].name", "spec.env[0].name", "spec.resources.requests.memory"},
+		},
+		{
+			name: "invalid annotation values",
+			mutate: func(wl *workloadv1alpha1.Workload) {
+				wl.SetAnnotations(map[string]string{
+					workloadv1alpha1.AnnotationBuildGitSecret:      "Not_Valid",
+					workloadv1alpha1.AnnotationBuildWorkspaceClaim: "shared-data",
+				})
+			},
+			fields: []string{"metadata.annotations[tekton.platform/build-git-secret]"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			wl := validWorkload()
+			tc.mutate(wl)
+			var got []string
+			for _, err := range ValidateWorkload(wl) {
+				got = append(got, err.Field)
+			}
+			assert.Equal(t, tc.fields, got)
+		})
+	}
+}
+
+func TestValidator_ValidateCreate(t *testing.T) {
+	wl := validWorkload()
+	wl.Spec.Source = nil
+	_, err := (&Validator{}).ValidateCreate(context.Background(), wl)
+	assert.True(t, apierrors.IsInvalid(err), "expected an Invalid status error, got %v", err)
+
+	_, err = (&Validator{}).ValidateCreate(context.Background(), validWorkload())
+	assert.NoError(t, err)
+}
+
+func TestValidator_ValidateUpdate(t *testing.T) {
+	// A Workload stored before the current rules; only its metadata changes.
+	old := validWorkload()
+	old.Spec.Env = []workloadv1alpha1.EnvVar{{Name: "1BAD", Value: "x"}}
+	updated := old.DeepCopy()
+	updated.Finalizers = []string{"tekton.platform/finalizer"}
+	updated.Labels = map[string]string{"team": "a"}
+	_, err := (&Validator{}).ValidateUpdate(context.Background(), old, updated)
+	assert.NoError(t, err)
+
+	// Any spec change validates the whole spec again.
+	updated.Spec.Source.Git.Ref.Branch = "develop"
+	_, err = (&Validator{}).ValidateUpdate(context.Background(), old, updated)
+	assert.True(t, apierrors.IsInvalid(err), "expected an Invalid status error, got %v", err)
+
+	_, err = (&Validator{}).ValidateUpdate(context.Background(), validWorkload(), validWorkload())
+	assert.NoError(t, err)
+}